@@ -3,8 +3,6 @@ package cli
 import (
 	"flag"
 	"fmt"
-	"log"
-	"os/exec"
 	"strings"
 )
 
@@ -14,10 +12,27 @@ type Commands struct {
 	Files                        []string
 	ExternalLinksToIgnore        []string
 	InternalLinksToIgnore        []string
+	FilesToCheck                 []string
 	FilesToIgnore                []string
 	FilesToIgnoreInternalLinksIn []string
 	Timeout                      int
 	RequestRepeats               int
+	Concurrency                  int
+	Parser                       string
+	SlugStyle                    string
+	RequestsPerSecond            float64
+	MaxInFlight                  int
+	CacheFile                    string
+	NoCache                      bool
+	NoGitignore                  bool
+	Since                        string
+	AddedLinesOnly               bool
+	Fix                          bool
+	DryRun                       bool
+	FailOn                       string
+	ClearCache                   bool
+	OutputFormat                 string
+	OutputFile                   string
 	AllowRedirect                bool
 	AllowCodeBlocks              bool
 	IgnoreExternal               bool
@@ -31,13 +46,30 @@ func ParseCommands() Commands {
 	configFile := flag.String("config-file", "milv.config.yaml", "The config file for bot")
 	externalLinksToIgnore := flag.String("external-links-to-ignore", "", "The list of external links to ignore")
 	internalLinksToIgnore := flag.String("internal-links-to-ignore", "", "The list of internal links to ignore")
+	filesToCheck := flag.String("files-to-check", "", "Glob patterns of files to check, patterns starting with ! exclude files")
 	filesToIgnore := flag.String("files-to-ignore", "", "The files to ignore")
 	timeout := flag.Int("timeout", 0, "Timeout for http.get reguest")
 	requestRepeats := flag.Int("request-repeats", 0, "Times reguest failuring links")
+	concurrency := flag.Int("concurrency", 0, "Number of files and links validated in parallel")
+	parser := flag.String("parser", "", "Parser of markdown files: commonmark or regex")
+	slugStyle := flag.String("slug-style", "", "Style of header anchors: github, gitlab, hugo or docusaurus")
+	requestsPerSecond := flag.Float64("requests-per-second", 0, "Maximum number of requests per second sent to a single host")
+	maxInFlight := flag.Int("max-in-flight", 0, "Maximum number of concurrent requests sent to a single host")
 	allowRedirect := flag.Bool("allow-redirect", false, "Allow redirect")
 	allowCodeBlocks := flag.Bool("allow-code-blocks", false, "Allow links in code blocks to check")
 	ignoreInternal := flag.Bool("ignore-internal", false, "Ignore internal links")
 	ignoreExternal := flag.Bool("ignore-external", false, "Ignore external links")
+	cacheFile := flag.String("cache-file", "", "The file with results of external links checks from previous runs, the cache is used only if it is set")
+	noCache := flag.Bool("no-cache", false, "Don't read and write the cache file")
+	noGitignore := flag.Bool("no-gitignore", false, "Check files ignored by .gitignore files")
+	since := flag.String("since", "", "Check only files changed since the git ref, such as origin/main")
+	addedLinesOnly := flag.Bool("added-lines-only", false, "Check only links in lines added since the git ref given by -since")
+	fix := flag.Bool("fix", false, "Rewrite links which can be fixed: moved files, suggested anchors, permanent redirects and https upgrades")
+	dryRun := flag.Bool("dry-run", false, "With -fix, write fixes as the unified diff instead of rewriting files")
+	failOn := flag.String("fail-on", "", "The lowest severity of problems which fail the check: error, warning or info")
+	clearCache := flag.Bool("clear-cache", false, "Remove results from previous runs before checking links")
+	outputFormat := flag.String("output-format", "", "Format of the report: table, json, junit or sarif")
+	outputFile := flag.String("output-file", "", "The file to write the report to instead of the standard output")
 	verbose := flag.Bool("v", false, "Enable verbose logging")
 
 	flag.Parse()
@@ -52,43 +84,37 @@ func ParseCommands() Commands {
 		*configFile = fmt.Sprintf("%s/%s", *basePath, *configFile)
 	}
 
-	if len(files) == 0 {
-		out := runCmd("find . -name \"*.md\"", true)
-		files = strings.Split(string(out), "\n")
-		if len(files) > 0 {
-			files = files[:len(files)-1]
-		}
-	}
-
 	return Commands{
 		BasePath:              *basePath,
 		ConfigFile:            *configFile,
 		Files:                 files,
 		ExternalLinksToIgnore: strings.Split(*externalLinksToIgnore, ","),
 		InternalLinksToIgnore: strings.Split(*internalLinksToIgnore, ","),
+		FilesToCheck:          strings.Split(*filesToCheck, ","),
 		FilesToIgnore:         strings.Split(*filesToIgnore, ","),
 		Timeout:               *timeout,
 		RequestRepeats:        *requestRepeats,
+		Concurrency:           *concurrency,
+		Parser:                *parser,
+		SlugStyle:             *slugStyle,
+		RequestsPerSecond:     *requestsPerSecond,
+		MaxInFlight:           *maxInFlight,
 		AllowRedirect:         *allowRedirect,
 		AllowCodeBlocks:       *allowCodeBlocks,
 		IgnoreExternal:        *ignoreExternal,
 		IgnoreInternal:        *ignoreInternal,
+		CacheFile:             *cacheFile,
+		NoCache:               *noCache,
+		NoGitignore:           *noGitignore,
+		Since:                 *since,
+		AddedLinesOnly:        *addedLinesOnly,
+		Fix:                   *fix,
+		DryRun:                *dryRun,
+		FailOn:                *failOn,
+		ClearCache:            *clearCache,
+		OutputFormat:          *outputFormat,
+		OutputFile:            *outputFile,
 		Verbose:               *verbose,
 		FlagsSet:              flagset,
 	}
 }
-
-func runCmd(cmd string, shell bool) []byte {
-	if shell {
-		out, err := exec.Command("/bin/bash", "-c", cmd).Output()
-		if err != nil {
-			log.Fatal(err)
-		}
-		return out
-	}
-	out, err := exec.Command(cmd).Output()
-	if err != nil {
-		log.Fatal(err)
-	}
-	return out
-}
@@ -1,12 +1,12 @@
 module github.com/kyma-incubator/milv
 
-go 1.17
+go 1.18
 
 require (
 	github.com/olekukonko/tablewriter v0.0.0-20180506121414-d4647c9c7a84
 	github.com/pkg/errors v0.8.0
-	github.com/schollz/closestmatch v2.1.0+incompatible
 	github.com/stretchr/testify v1.3.0
+	github.com/yuin/goldmark v1.4.12
 	golang.org/x/net v0.0.0-20180811021610-c39426892332
 	gopkg.in/yaml.v2 v2.2.1
 )
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/kyma-incubator/milv/cli"
@@ -15,12 +14,35 @@ func main() {
 		panic(err)
 	}
 
-	files, _ := milv.NewFiles(cliCommands.Files, config)
+	filePaths := cliCommands.Files
+	if len(filePaths) == 0 {
+		filePaths, err = milv.FindFiles(config)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	files, err := milv.NewFiles(filePaths, config)
+	if err != nil {
+		panic(err)
+	}
 	files.Run(cliCommands.Verbose)
 
-	if files.Summary() {
-		os.Exit(1)
+	if config.Fix && config.DryRun {
+		if err := files.WriteFixes(os.Stdout); err != nil {
+			panic(err)
+		}
+	} else if config.Fix {
+		if err := files.Fix(); err != nil {
+			panic(err)
+		}
 	}
 
-	fmt.Println("NO ISSUES :-)")
+	if err := milv.WriteReport(files, config); err != nil {
+		panic(err)
+	}
+
+	if files.FailedOn(config.FailOn) {
+		os.Exit(1)
+	}
 }
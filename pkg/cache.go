@@ -0,0 +1,75 @@
+package pkg
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ResultCache keeps results of external links checks, so every URL is requested only once,
+// even if it's linked from many files. The result of the first check is shared,
+// so the timeout and request repeats of the first checked link are used.
+// Results are also read from and written to the optional DiskCache.
+type ResultCache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	store   *DiskCache
+}
+
+type cacheEntry struct {
+	ready  chan struct{}
+	result checkResult
+}
+
+func NewResultCache(store *DiskCache) *ResultCache {
+	return &ResultCache{entries: map[string]*cacheEntry{}, store: store}
+}
+
+// Get returns the cached result for the key or calls check to get it.
+// Concurrent calls for the same key wait for the first check to finish.
+func (c *ResultCache) Get(key string, check func() checkResult) checkResult {
+	if c == nil {
+		return check()
+	}
+
+	c.mu.Lock()
+	entry, found := c.entries[key]
+	if !found {
+		entry = &cacheEntry{ready: make(chan struct{})}
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	if found {
+		<-entry.ready
+		return entry.result
+	}
+
+	if result, ok := c.store.Get(key); ok {
+		entry.result = result
+	} else {
+		entry.result = check()
+		c.store.Put(key, entry.result)
+	}
+	close(entry.ready)
+	return entry.result
+}
+
+// Save writes results to the DiskCache, if any
+func (c *ResultCache) Save() error {
+	if c == nil {
+		return nil
+	}
+	return c.store.Save()
+}
+
+// cacheKey normalizes the URL to scheme, host and path. Anchors of the website are fetched
+// only when they are needed, so such results are cached separately.
+func cacheKey(url *url.URL, withAnchors bool) string {
+	key := fmt.Sprintf("%s://%s%s", strings.ToLower(url.Scheme), strings.ToLower(url.Host), url.Path)
+	if withAnchors {
+		key += "#"
+	}
+	return key
+}
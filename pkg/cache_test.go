@@ -0,0 +1,107 @@
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCache(t *testing.T) {
+	t.Run("Same URL is requested once", func(t *testing.T) {
+		//GIVEN
+		var mu sync.Mutex
+		requests := map[string]int{}
+		svc := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			mu.Lock()
+			requests[request.URL.Path]++
+			mu.Unlock()
+			if request.URL.Path == "/missing" {
+				writer.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writer.WriteHeader(http.StatusOK)
+		}))
+		defer svc.Close()
+
+		cache := NewResultCache(nil)
+		pool := newWorkerPool(4)
+		var validators []*Validator
+		for i := 0; i < 3; i++ {
+			v := NewValidator(http.Client{}, &waitMock{})
+			v.pool = pool
+			v.cache = cache
+			validators = append(validators, v)
+		}
+
+		links := []Link{
+			{AbsPath: svc.URL + "/page", TypeOf: ExternalLink},
+			{AbsPath: svc.URL + "/page?tab=1", TypeOf: ExternalLink},
+			{AbsPath: svc.URL + "/missing", TypeOf: ExternalLink},
+			{AbsPath: svc.URL + "/page", TypeOf: ExternalLink},
+		}
+
+		//WHEN
+		var results [][]Link
+		for _, v := range validators {
+			results = append(results, v.Links(links))
+		}
+
+		//THEN
+		assert.Equal(t, map[string]int{"/page": 1, "/missing": 1}, requests)
+		for _, result := range results {
+			require.Len(t, result, 4)
+			assert.True(t, result[0].Result.Status)
+			assert.True(t, result[1].Result.Status)
+			assert.Equal(t, LinkResult{Status: false, Message: "404 Not Found", Kind: HTTPStatus}, result[2].Result)
+			assert.True(t, result[3].Result.Status)
+		}
+	})
+
+	t.Run("Anchors are checked per link", func(t *testing.T) {
+		//GIVEN
+		requests := 0
+		svc := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			requests++
+			_, _ = writer.Write([]byte(`<html><body><h2 id="installation">Installation</h2></body></html>`))
+		}))
+		defer svc.Close()
+
+		v := NewValidator(http.Client{}, &waitMock{})
+		v.cache = NewResultCache(nil)
+
+		links := []Link{
+			{AbsPath: svc.URL + "/docs#installation", TypeOf: ExternalLink},
+			{AbsPath: svc.URL + "/docs#instalation", TypeOf: ExternalLink},
+		}
+
+		//WHEN
+		result := v.Links(links)
+
+		//THEN
+		assert.Equal(t, 1, requests)
+		assert.True(t, result[0].Result.Status)
+		assert.False(t, result[1].Result.Status)
+		assert.Equal(t, "The specified anchor doesn't exist on the website. Did you mean #installation?", result[1].Result.Message)
+	})
+
+	t.Run("Cache key is normalized", func(t *testing.T) {
+		//GIVEN
+		first, err := url.Parse("HTTPS://GitHub.com/kyma-incubator/milv?tab=readme#overview")
+		require.NoError(t, err)
+		second, err := url.Parse("https://github.com/kyma-incubator/milv")
+		require.NoError(t, err)
+
+		//WHEN
+		firstKey, secondKey := cacheKey(first, false), cacheKey(second, false)
+
+		//THEN
+		assert.Equal(t, "https://github.com/kyma-incubator/milv", firstKey)
+		assert.Equal(t, firstKey, secondKey)
+		assert.NotEqual(t, firstKey, cacheKey(first, true))
+	})
+}
@@ -2,8 +2,10 @@ package pkg
 
 import (
 	"io/ioutil"
+	"path/filepath"
 	"time"
 
+	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 
 	"github.com/kyma-incubator/milv/cli"
@@ -11,18 +13,37 @@ import (
 
 type Config struct {
 	BasePath                     string
-	Files                        []File        `yaml:"files"`
-	Backoff                      time.Duration `yaml:"backoff"`
-	ExternalLinksToIgnore        []string      `yaml:"external-links-to-ignore"`
-	InternalLinksToIgnore        []string      `yaml:"internal-links-to-ignore"`
-	FilesToIgnore                []string      `yaml:"files-to-ignore"`
-	FilesToIgnoreInternalLinksIn []string      `yaml:"files-to-ignore-internal-links-in"`
-	Timeout                      int           `yaml:"timeout"`
-	RequestRepeats               int           `yaml:"request-repeats"`
-	AllowRedirect                bool          `yaml:"allow-redirect"`
-	AllowCodeBlocks              bool          `yaml:"allow-code-blocks"`
-	IgnoreExternal               bool          `yaml:"ignore-external"`
-	IgnoreInternal               bool          `yaml:"ignore-internal"`
+	Files                        []File          `yaml:"files"`
+	Backoff                      time.Duration   `yaml:"backoff"`
+	ExternalLinksToIgnore        []string        `yaml:"external-links-to-ignore"`
+	InternalLinksToIgnore        []string        `yaml:"internal-links-to-ignore"`
+	FilesToCheck                 []string        `yaml:"files-to-check"`
+	FilesToIgnore                []string        `yaml:"files-to-ignore"`
+	FilesToIgnoreInternalLinksIn []string        `yaml:"files-to-ignore-internal-links-in"`
+	Timeout                      int             `yaml:"timeout"`
+	RequestRepeats               int             `yaml:"request-repeats"`
+	Concurrency                  int             `yaml:"concurrency"`
+	Parser                       string          `yaml:"parser"`
+	SlugStyle                    string          `yaml:"slug-style"`
+	AllowRedirect                bool            `yaml:"allow-redirect"`
+	AllowCodeBlocks              bool            `yaml:"allow-code-blocks"`
+	IgnoreExternal               bool            `yaml:"ignore-external"`
+	IgnoreInternal               bool            `yaml:"ignore-internal"`
+	RateLimit                    RateLimitConfig `yaml:"rate-limit"`
+	Redirects                    RedirectConfig  `yaml:"redirects"`
+	Severities                   Severities      `yaml:"severities"`
+	FailOn                       Severity        `yaml:"fail-on"`
+	Cache                        CacheConfig     `yaml:"cache"`
+	OutputFormat                 string          `yaml:"output-format"`
+	OutputFile                   string          `yaml:"output-file"`
+	NoGitignore                  bool            `yaml:"no-gitignore"`
+	Since                        string          `yaml:"-"`
+	AddedLinesOnly               bool            `yaml:"-"`
+	Fix                          bool            `yaml:"-"`
+	DryRun                       bool            `yaml:"-"`
+
+	ignoreFiles *ignoreFiles
+	diff        *Diff
 }
 
 func NewConfig(commands cli.Commands) (*Config, error) {
@@ -43,7 +64,48 @@ func NewConfig(commands cli.Commands) (*Config, error) {
 			return nil, err
 		}
 	}
-	return config.combine(commands), nil
+
+	config = config.combine(commands)
+	if config.Parser != CommonMarkParser && config.Parser != RegexParser {
+		return nil, errors.Errorf("Unknown parser %q", config.Parser)
+	}
+	if _, err := NewSlugger(config.SlugStyle); err != nil {
+		return nil, err
+	}
+	if err := validateRedirectPolicies(config.Redirects); err != nil {
+		return nil, err
+	}
+	if err := validateSeverities(config.Severities, config.FailOn); err != nil {
+		return nil, err
+	}
+	if err := validateIgnorePatterns(config.ExternalLinksToIgnore, config.InternalLinksToIgnore); err != nil {
+		return nil, err
+	}
+	for _, file := range config.Files {
+		if file.Config == nil {
+			continue
+		}
+		if err := validateIgnorePatterns(file.Config.ExternalLinksToIgnore, file.Config.InternalLinksToIgnore); err != nil {
+			return nil, errors.Wrapf(err, "Invalid configuration of the %s file", file.RelPath)
+		}
+		if file.Config.SlugStyle != "" {
+			if _, err := NewSlugger(file.Config.SlugStyle); err != nil {
+				return nil, errors.Wrapf(err, "Invalid configuration of the %s file", file.RelPath)
+			}
+		}
+	}
+	if config.AddedLinesOnly && config.Since == "" {
+		return nil, errors.New("The added-lines-only parameter requires the since parameter")
+	}
+	if config.DryRun && !config.Fix {
+		return nil, errors.New("The dry-run parameter requires the fix parameter")
+	}
+	if config.Since != "" {
+		if config.diff, err = NewDiff(config.rootDir(), config.Since); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
 }
 
 func (c *Config) combine(commands cli.Commands) *Config {
@@ -83,6 +145,116 @@ func (c *Config) combine(commands cli.Commands) *Config {
 		ignoreInternal = c.IgnoreInternal
 	}
 
+	var concurrency int
+	if commands.FlagsSet["concurrency"] {
+		concurrency = commands.Concurrency
+	} else {
+		concurrency = c.Concurrency
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var parser string
+	if commands.FlagsSet["parser"] {
+		parser = commands.Parser
+	} else {
+		parser = c.Parser
+	}
+	if parser == "" {
+		parser = CommonMarkParser
+	}
+
+	var slugStyle string
+	if commands.FlagsSet["slug-style"] {
+		slugStyle = commands.SlugStyle
+	} else {
+		slugStyle = c.SlugStyle
+	}
+	if slugStyle == "" {
+		slugStyle = GitHubSlugStyle
+	}
+
+	rateLimit := c.RateLimit
+	if commands.FlagsSet["requests-per-second"] {
+		rateLimit.RequestsPerSecond = commands.RequestsPerSecond
+	}
+	if commands.FlagsSet["max-in-flight"] {
+		rateLimit.MaxInFlight = commands.MaxInFlight
+	}
+	if rateLimit.MaxRetryAfter <= 0 {
+		rateLimit.MaxRetryAfter = 1 * time.Minute
+	}
+
+	redirects := c.Redirects
+	if redirects.MaxHops <= 0 {
+		redirects.MaxHops = DefaultMaxRedirects
+	}
+	if redirects.Permanent == "" {
+		redirects.Permanent = PassRedirect
+	}
+	if redirects.Temporary == "" {
+		redirects.Temporary = PassRedirect
+	}
+
+	var failOn Severity
+	if commands.FlagsSet["fail-on"] {
+		failOn = Severity(commands.FailOn)
+	} else {
+		failOn = c.FailOn
+	}
+	if failOn == "" {
+		failOn = ErrorSeverity
+	}
+
+	cache := c.Cache
+	if commands.FlagsSet["no-cache"] {
+		cache.Disabled = commands.NoCache
+	}
+	if commands.FlagsSet["cache-file"] {
+		cache.File = commands.CacheFile
+	}
+	// the cache is optional, it's used only if its file is set
+	if cache.File != "" && commands.BasePath != "" && !filepath.IsAbs(cache.File) {
+		cache.File = filepath.Join(commands.BasePath, cache.File)
+	}
+	if cache.SuccessTTL == 0 {
+		cache.SuccessTTL = 24 * time.Hour
+	}
+	cache.Clear = commands.ClearCache
+
+	var outputFormat, outputFile string
+	if commands.FlagsSet["output-format"] {
+		outputFormat = commands.OutputFormat
+	} else {
+		outputFormat = c.OutputFormat
+	}
+	if outputFormat == "" {
+		outputFormat = TableFormat
+	}
+	if commands.FlagsSet["output-file"] {
+		outputFile = commands.OutputFile
+	} else {
+		outputFile = c.OutputFile
+	}
+
+	var filesToCheck []string
+	if commands.FlagsSet["files-to-check"] {
+		filesToCheck = commands.FilesToCheck
+	} else {
+		filesToCheck = c.FilesToCheck
+	}
+	if len(filesToCheck) == 0 {
+		filesToCheck = DefaultFilesToCheck
+	}
+
+	var noGitignore bool
+	if commands.FlagsSet["no-gitignore"] {
+		noGitignore = commands.NoGitignore
+	} else {
+		noGitignore = c.NoGitignore
+	}
+
 	backoff := 1 * time.Second
 	if c.Backoff > 0 {
 		backoff = c.Backoff
@@ -90,11 +262,20 @@ func (c *Config) combine(commands cli.Commands) *Config {
 
 	return &Config{
 		BasePath:                     commands.BasePath,
+		Concurrency:                  concurrency,
+		Parser:                       parser,
+		SlugStyle:                    slugStyle,
 		Backoff:                      backoff,
 		Files:                        c.Files,
 		ExternalLinksToIgnore:        unique(append(c.ExternalLinksToIgnore, commands.ExternalLinksToIgnore...)),
 		InternalLinksToIgnore:        unique(append(c.InternalLinksToIgnore, commands.InternalLinksToIgnore...)),
 		FilesToIgnoreInternalLinksIn: unique(append(c.FilesToIgnoreInternalLinksIn, commands.FilesToIgnoreInternalLinksIn...)),
+		FilesToCheck:                 filesToCheck,
+		NoGitignore:                  noGitignore,
+		Since:                        commands.Since,
+		AddedLinesOnly:               commands.AddedLinesOnly,
+		Fix:                          commands.Fix,
+		DryRun:                       commands.DryRun,
 		FilesToIgnore:                unique(append(c.FilesToIgnore, commands.FilesToIgnore...)),
 		Timeout:                      timeout,
 		RequestRepeats:               requestRepeats,
@@ -102,5 +283,28 @@ func (c *Config) combine(commands cli.Commands) *Config {
 		AllowCodeBlocks:              allowCodeBlocks,
 		IgnoreExternal:               ignoreExternal,
 		IgnoreInternal:               ignoreInternal,
+		RateLimit:                    rateLimit,
+		Redirects:                    redirects,
+		Severities:                   c.Severities,
+		FailOn:                       failOn,
+		Cache:                        cache,
+		OutputFormat:                 outputFormat,
+		OutputFile:                   outputFile,
+	}
+}
+
+// rootDir returns the directory of files to check
+func (c *Config) rootDir() string {
+	if c.BasePath == "" {
+		return "."
+	}
+	return c.BasePath
+}
+
+// ignores returns .gitignore and .milvignore files of the base path, they are read once for the whole run
+func (c *Config) ignores() *ignoreFiles {
+	if c.ignoreFiles == nil {
+		c.ignoreFiles = newIgnoreFiles(c.rootDir(), !c.NoGitignore)
 	}
+	return c.ignoreFiles
 }
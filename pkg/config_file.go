@@ -3,12 +3,17 @@ package pkg
 import (
 	"fmt"
 	"path"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 )
 
 type FileConfig struct {
 	BasePath              string
+	Concurrency           int
+	Parser                string
+	SlugStyle             string        `yaml:"slug-style"`
 	Backoff               time.Duration `yaml:"backoff"`
 	ExternalLinksToIgnore []string      `yaml:"external-links-to-ignore"`
 	InternalLinksToIgnore []string      `yaml:"internal-links-to-ignore"`
@@ -18,6 +23,9 @@ type FileConfig struct {
 	AllowCodeBlocks       *bool         `yaml:"allow-code-blocks"`
 	IgnoreExternal        *bool         `yaml:"ignore-external"`
 	IgnoreInternal        *bool         `yaml:"ignore-internal"`
+
+	diff           *Diff
+	addedLinesOnly bool
 }
 
 func NewFileConfig(filePath string, config *Config) FileConfig {
@@ -45,12 +53,20 @@ func NewFileConfig(filePath string, config *Config) FileConfig {
 	backoff := getDefaultDurationIfNotProvided(cfg.Backoff, fileCfg.Backoff)
 	ignoreInternal := getInternalIgnorePolicy(filePath, cfg, fileCfg)
 	ignoreExternal := getDefaultBoolIfNil(cfg.IgnoreExternal, fileCfg.IgnoreExternal)
+	slugStyle := getDefaultStringIfEmpty(cfg.SlugStyle, fileCfg.SlugStyle)
 
 	externalLinksToIgnore := getExternalLinksToIgnore(cfg, file.Config)
 	internalLinksToIgnore := getInternalLinksToIgnore(cfg, file.Config)
+	if linksToIgnore := getLinksToIgnoreByIgnoreFiles(filePath, config); len(linksToIgnore) > 0 {
+		externalLinksToIgnore = unique(append(externalLinksToIgnore, linksToIgnore...))
+		internalLinksToIgnore = unique(append(internalLinksToIgnore, linksToIgnore...))
+	}
 
 	return FileConfig{
 		BasePath:              config.BasePath,
+		Concurrency:           config.Concurrency,
+		Parser:                config.Parser,
+		SlugStyle:             slugStyle,
 		Backoff:               backoff,
 		ExternalLinksToIgnore: externalLinksToIgnore,
 		InternalLinksToIgnore: internalLinksToIgnore,
@@ -60,6 +76,8 @@ func NewFileConfig(filePath string, config *Config) FileConfig {
 		AllowCodeBlocks:       &allowCodeBlocks,
 		IgnoreExternal:        &ignoreExternal,
 		IgnoreInternal:        &ignoreInternal,
+		diff:                  config.diff,
+		addedLinesOnly:        config.AddedLinesOnly,
 	}
 }
 
@@ -90,6 +108,15 @@ func getInternalLinksToIgnore(config Config, fileConfig *FileConfig) []string {
 	return internalLinksToIgnore
 }
 
+// getLinksToIgnoreByIgnoreFiles returns link rules of .milvignore files which apply to the file
+func getLinksToIgnoreByIgnoreFiles(filePath string, config *Config) []string {
+	relPath, err := filepath.Rel(config.rootDir(), filePath)
+	if err != nil || strings.HasPrefix(relPath, "..") {
+		return nil
+	}
+	return config.ignores().linksToIgnore(filepath.ToSlash(relPath))
+}
+
 func getInternalIgnorePolicy(filepath string, config Config, fileConfig FileConfig) bool {
 	internalIgnore := config.IgnoreInternal
 
@@ -104,14 +131,31 @@ func getInternalIgnorePolicy(filepath string, config Config, fileConfig FileConf
 	return internalIgnore
 }
 
+// isFileIgnored checks if the file is in the list of files and directories to ignore
 func isFileIgnored(filePath string, filesToIgnore []string) bool {
-	for _, fileToIgnore := range filesToIgnore {
-		if strings.HasPrefix(fileToIgnore, ".") {
-			if checkIfFileIsInIgnorePath(fileToIgnore, filePath) {
+	return isPathIgnored(filePath, false, filesToIgnore)
+}
+
+// isPathIgnored matches the file or the directory against the list of paths to ignore:
+//   - glob patterns, such as **/vendor/** or docs/*.md, match the whole path
+//   - paths starting with ., such as ./docs, match the path and everything inside it
+//   - other names, such as vendor or README.md, match any directory or file with this name
+func isPathIgnored(filePath string, dir bool, pathsToIgnore []string) bool {
+	cleanFilePath := path.Clean(filePath)
+	for _, pathToIgnore := range pathsToIgnore {
+		switch {
+		case pathToIgnore == "":
+			continue
+		case strings.ContainsAny(pathToIgnore, "*?"):
+			if matchesGlob(pathToIgnore, cleanFilePath, dir) {
 				return true
 			}
-		} else {
-			if checkIfFilePathContainsIgnoredDir(fileToIgnore, filePath) {
+		case strings.HasPrefix(pathToIgnore, "."):
+			if checkIfFileIsInIgnorePath(pathToIgnore, cleanFilePath) {
+				return true
+			}
+		default:
+			if checkIfFilePathContainsIgnoredName(pathToIgnore, cleanFilePath) {
 				return true
 			}
 		}
@@ -126,6 +170,13 @@ func getDefaultBoolIfNil(defaultValue bool, value *bool) bool {
 	return *value
 }
 
+func getDefaultStringIfEmpty(defaultValue, value string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
 func getDefaultIntIfNil(defaultValue int, value *int) int {
 	if value == nil {
 		return defaultValue
@@ -142,14 +193,17 @@ func getDefaultDurationIfNotProvided(defaultValue, value time.Duration) time.Dur
 
 func checkIfFileIsInIgnorePath(fileToIgnore, filePath string) bool {
 	startingPath := path.Clean(fileToIgnore)
-	cleanFilePath := path.Clean(filePath)
+	return filePath == startingPath || strings.HasPrefix(filePath, startingPath+"/")
+}
 
-	output := strings.HasPrefix(cleanFilePath, startingPath)
-	return output
+func checkIfFilePathContainsIgnoredName(fileToIgnore, filePath string) bool {
+	rootedFilePath := fmt.Sprintf(`/%s/`, filePath)
+	nameToIgnore := fmt.Sprintf(`/%s/`, strings.Trim(fileToIgnore, "/"))
+	return strings.Contains(rootedFilePath, nameToIgnore)
 }
 
-func checkIfFilePathContainsIgnoredDir(fileToIgnore, filePath string) bool {
-	rootedFilePath := fmt.Sprintf(`/%s`, filePath)
-	dirToIgnore := fmt.Sprintf(`/%s/`, fileToIgnore)
-	return strings.Contains(rootedFilePath, dirToIgnore)
+// matchesGlob matches the path, the directory also matches patterns of files inside it, such as vendor/**
+func matchesGlob(glob, filePath string, dir bool) bool {
+	re := regexp.MustCompile(globToRegex(strings.TrimPrefix(glob, "./")))
+	return re.MatchString(filePath) || dir && re.MatchString(filePath+"/")
 }
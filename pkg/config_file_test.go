@@ -107,10 +107,12 @@ func TestNewFileConfig(t *testing.T) {
 			IgnoreExternal:  false,
 			IgnoreInternal:  true,
 			Backoff:         5 * time.Hour,
+			SlugStyle:       GitHubSlugStyle,
 		}
 
 		expectedCfg := FileConfig{
 			BasePath:        "path",
+			SlugStyle:       GitHubSlugStyle,
 			Timeout:         &timeout,
 			RequestRepeats:  &requestRepeats,
 			AllowRedirect:   &trueBool,
@@ -145,6 +147,7 @@ func TestNewFileConfig(t *testing.T) {
 					IgnoreExternal:  &trueBool,
 					IgnoreInternal:  &falseBool,
 					Backoff:         10 * time.Second,
+					SlugStyle:       HugoSlugStyle,
 				}},
 		}
 
@@ -157,9 +160,11 @@ func TestNewFileConfig(t *testing.T) {
 			IgnoreExternal:  false,
 			IgnoreInternal:  true,
 			Backoff:         1 * time.Second,
+			SlugStyle:       GitHubSlugStyle,
 		}
 
 		expectedCfg := FileConfig{
+			SlugStyle:             HugoSlugStyle,
 			ExternalLinksToIgnore: []string{},
 			InternalLinksToIgnore: []string{},
 			Timeout:               &timeout,
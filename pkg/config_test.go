@@ -1,6 +1,8 @@
 package pkg
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -39,4 +41,167 @@ func TestConfig(t *testing.T) {
 		assert.ElementsMatch(t, expected.InternalLinksToIgnore, result.InternalLinksToIgnore)
 		assert.ElementsMatch(t, expected.FilesToIgnore, result.FilesToIgnore)
 	})
+	t.Run("Concurrency", func(t *testing.T) {
+		commands := cli.Commands{
+			ConfigFile: "test-markdowns/milv-test.config.yaml",
+		}
+
+		result, err := NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Concurrency)
+
+		commands.Concurrency = 8
+		commands.FlagsSet = map[string]bool{"concurrency": true}
+
+		result, err = NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, 8, result.Concurrency)
+	})
+	t.Run("Parser", func(t *testing.T) {
+		commands := cli.Commands{
+			ConfigFile: "test-markdowns/milv-test.config.yaml",
+		}
+
+		result, err := NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, CommonMarkParser, result.Parser)
+
+		commands.Parser = RegexParser
+		commands.FlagsSet = map[string]bool{"parser": true}
+
+		result, err = NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, RegexParser, result.Parser)
+
+		commands.Parser = "unknown"
+
+		_, err = NewConfig(commands)
+		assert.Error(t, err)
+	})
+	t.Run("Slug Style", func(t *testing.T) {
+		commands := cli.Commands{
+			ConfigFile: "test-markdowns/milv-test.config.yaml",
+		}
+
+		result, err := NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, GitHubSlugStyle, result.SlugStyle)
+
+		commands.SlugStyle = HugoSlugStyle
+		commands.FlagsSet = map[string]bool{"slug-style": true}
+
+		result, err = NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, HugoSlugStyle, result.SlugStyle)
+
+		commands.SlugStyle = "unknown"
+
+		_, err = NewConfig(commands)
+		assert.Error(t, err)
+
+		configFile := filepath.Join(t.TempDir(), "milv.config.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte("files:\n  - path: ./README.md\n    config:\n      slug-style: gihtub\n"), 0644))
+
+		_, err = NewConfig(cli.Commands{ConfigFile: configFile})
+		assert.EqualError(t, err, `Invalid configuration of the ./README.md file: Unknown slug style "gihtub"`)
+	})
+	t.Run("Redirects", func(t *testing.T) {
+		commands := cli.Commands{
+			ConfigFile: "test-markdowns/milv-test.config.yaml",
+		}
+
+		result, err := NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, RedirectConfig{MaxHops: DefaultMaxRedirects, Permanent: PassRedirect, Temporary: PassRedirect}, result.Redirects)
+
+		config := &Config{Redirects: RedirectConfig{Permanent: "ignore"}}
+		assert.Error(t, validateRedirectPolicies(config.combine(cli.Commands{}).Redirects))
+	})
+	t.Run("Severities", func(t *testing.T) {
+		commands := cli.Commands{
+			ConfigFile: "test-markdowns/milv-test.config.yaml",
+		}
+
+		result, err := NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, ErrorSeverity, result.FailOn)
+
+		commands.FailOn = "warning"
+		commands.FlagsSet = map[string]bool{"fail-on": true}
+
+		result, err = NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, WarningSeverity, result.FailOn)
+
+		commands.FailOn = "critical"
+
+		_, err = NewConfig(commands)
+		assert.Error(t, err)
+
+		assert.Error(t, validateSeverities(Severities{RequestTimeout: "ignore"}, ErrorSeverity))
+	})
+	t.Run("Files To Check", func(t *testing.T) {
+		commands := cli.Commands{
+			ConfigFile: "test-markdowns/milv-test.config.yaml",
+		}
+
+		result, err := NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultFilesToCheck, result.FilesToCheck)
+
+		commands.FilesToCheck = []string{"docs/**/*.md", "!**/vendor/**"}
+		commands.FlagsSet = map[string]bool{"files-to-check": true}
+
+		result, err = NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"docs/**/*.md", "!**/vendor/**"}, result.FilesToCheck)
+	})
+	t.Run("Ignore Patterns", func(t *testing.T) {
+		commands := cli.Commands{
+			ConfigFile:            "test-markdowns/milv-test.config.yaml",
+			ExternalLinksToIgnore: []string{"re:^https?://localhost(:\\d+)?/", "glob:https://*.example.com/**"},
+		}
+
+		_, err := NewConfig(commands)
+		require.NoError(t, err)
+
+		commands.InternalLinksToIgnore = []string{"re:[unclosed"}
+
+		_, err = NewConfig(commands)
+		assert.Error(t, err)
+	})
+	t.Run("Cache", func(t *testing.T) {
+		commands := cli.Commands{
+			ConfigFile: "test-markdowns/milv-test.config.yaml",
+			BasePath:   "test-markdowns",
+		}
+
+		result, err := NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, CacheConfig{SuccessTTL: 24 * time.Hour}, result.Cache)
+
+		commands.CacheFile = ".milv-cache.json"
+		commands.FlagsSet = map[string]bool{"cache-file": true}
+
+		result, err = NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, CacheConfig{
+			File:       "test-markdowns/.milv-cache.json",
+			SuccessTTL: 24 * time.Hour,
+		}, result.Cache)
+
+		commands.CacheFile = "/tmp/milv.json"
+		commands.NoCache = true
+		commands.ClearCache = true
+		commands.FlagsSet = map[string]bool{"cache-file": true, "no-cache": true, "clear-cache": true}
+
+		result, err = NewConfig(commands)
+		require.NoError(t, err)
+		assert.Equal(t, CacheConfig{
+			Disabled:   true,
+			File:       "/tmp/milv.json",
+			SuccessTTL: 24 * time.Hour,
+			Clear:      true,
+		}, result.Cache)
+	})
 }
@@ -0,0 +1,236 @@
+package pkg
+
+import (
+	"bufio"
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// hunkPattern is the header of the hunk in the unified diff, such as @@ -10,2 +10,3 @@
+const hunkPattern = `^@@ -\d+(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`
+
+// Diff holds files changed since the git ref. Changes are read from the local repository with git plumbing
+// commands, so committed changes, staged changes and changes of tracked files in the working tree are included.
+// Paths are absolute, so they can be compared with paths of files and links.
+type Diff struct {
+	// added maps added, modified and renamed files to numbers of lines added to them
+	added map[string]map[int]bool
+	// renamed holds new paths of renamed files
+	renamed map[string]bool
+	// removed holds deleted files and previous paths of renamed files
+	removed map[string]bool
+}
+
+// NewDiff reads changes of files in the root directory since the merge base of the ref and HEAD,
+// the same changes as the pull request from HEAD to the ref shows
+func NewDiff(root, ref string) (*Diff, error) {
+	base, err := git(root, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error while reading changes since %s", ref)
+	}
+	base = strings.TrimSpace(base)
+
+	// git diff-index compares the working tree with the index by stat info, so the index is refreshed first,
+	// otherwise touched files without changes are reported as modified. The refresh fails in read-only
+	// repositories, where changes are read anyway.
+	_, _ = git(root, "update-index", "-q", "--refresh")
+
+	diff := &Diff{added: map[string]map[int]bool{}, renamed: map[string]bool{}, removed: map[string]bool{}}
+	statuses, err := git(root, "diff-index", "--name-status", "-z", "-M", "--relative", base)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error while reading changes since %s", ref)
+	}
+	if err := diff.readStatuses(root, statuses); err != nil {
+		return nil, errors.Wrapf(err, "Error while reading changes since %s", ref)
+	}
+
+	patch, err := git(root, "diff-index", "-p", "-U0", "-M", "--relative", "--no-color", "--no-ext-diff",
+		"--src-prefix=a/", "--dst-prefix=b/", base)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error while reading changes since %s", ref)
+	}
+	if err := diff.readPatch(root, patch); err != nil {
+		return nil, errors.Wrapf(err, "Error while reading changes since %s", ref)
+	}
+	return diff, nil
+}
+
+// readStatuses reads the output of git diff-index --name-status -z, where renamed and copied files
+// have two paths, and other files have one
+func (d *Diff) readStatuses(root, output string) error {
+	fields := strings.Split(strings.TrimSuffix(output, "\x00"), "\x00")
+	for i := 0; i < len(fields); i++ {
+		if fields[i] == "" {
+			continue
+		}
+		status := fields[i][:1]
+		paths := 1
+		if status == "R" || status == "C" {
+			paths = 2
+		}
+		if i+paths >= len(fields) {
+			return errors.Errorf("Unexpected output of git diff-index: %q", fields[i])
+		}
+
+		filePath := absPath(root, fields[i+paths])
+		switch status {
+		case "D":
+			d.removed[filePath] = true
+		case "R":
+			d.removed[absPath(root, fields[i+1])] = true
+			d.renamed[filePath] = true
+			d.addFile(filePath)
+		default:
+			d.addFile(filePath)
+		}
+		i += paths
+	}
+	return nil
+}
+
+// readPatch reads numbers of added lines from hunks of the unified diff without context lines
+func (d *Diff) readPatch(root, patch string) error {
+	hunk := regexp.MustCompile(hunkPattern)
+
+	filePath := ""
+	// oldLines and newLines are lines of the current hunk which aren't read yet
+	oldLines, newLines := 0, 0
+	scanner := bufio.NewScanner(strings.NewReader(patch))
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if oldLines > 0 || newLines > 0 {
+			switch {
+			case strings.HasPrefix(line, "-"):
+				oldLines--
+			case strings.HasPrefix(line, "+"):
+				newLines--
+			}
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			// git ends the name containing spaces with the tab
+			name := strings.TrimSuffix(strings.TrimPrefix(line, "+++ "), "\t")
+			if unquoted, err := strconv.Unquote(name); err == nil {
+				name = unquoted
+			}
+			filePath = ""
+			if name != "/dev/null" {
+				filePath = absPath(root, strings.TrimPrefix(name, "b/"))
+			}
+		case strings.HasPrefix(line, "@@ "):
+			matches := hunk.FindStringSubmatch(line)
+			if matches == nil {
+				return errors.Errorf("Unexpected hunk header: %q", line)
+			}
+			oldLines = hunkLength(matches[1])
+			start, _ := strconv.Atoi(matches[2])
+			newLines = hunkLength(matches[3])
+			if filePath == "" {
+				continue
+			}
+			d.addFile(filePath)
+			for number := start; number < start+newLines; number++ {
+				d.added[filePath][number] = true
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+func (d *Diff) addFile(filePath string) {
+	if d.added[filePath] == nil {
+		d.added[filePath] = map[int]bool{}
+	}
+}
+
+// hunkLength returns the number of lines of the hunk, which is omitted when it's 1
+func hunkLength(value string) int {
+	if value == "" {
+		return 1
+	}
+	length, _ := strconv.Atoi(value)
+	return length
+}
+
+// changed checks if the file was added, modified or renamed
+func (d *Diff) changed(filePath string) bool {
+	_, found := d.added[absPath("", filePath)]
+	return found
+}
+
+// selects checks if the file should be checked, because it was changed or it links to removed files
+func (d *Diff) selects(file *File) bool {
+	if d.changed(file.RelPath) {
+		return true
+	}
+	basePath := ""
+	if file.Config != nil {
+		basePath = file.Config.BasePath
+	}
+	for _, link := range file.parser.Links(basePath, file.Content, file.DirPath) {
+		if d.linksToRemovedFile(link) {
+			return true
+		}
+	}
+	return false
+}
+
+// keeps checks if the link of the file should be checked. Links to removed files are always checked.
+// Other links are checked only in changed files and, with addedLinesOnly, only in added lines.
+// Internal links of renamed files are checked in all lines, because they are relative to the new path.
+func (d *Diff) keeps(filePath string, link Link, addedLinesOnly bool) bool {
+	if d.linksToRemovedFile(link) {
+		return true
+	}
+	filePath = absPath("", filePath)
+	lines, changed := d.added[filePath]
+	switch {
+	case !changed:
+		return false
+	case !addedLinesOnly:
+		return true
+	case d.renamed[filePath] && link.TypeOf == InternalLink:
+		return true
+	}
+	return lines[link.Line]
+}
+
+func (d *Diff) linksToRemovedFile(link Link) bool {
+	if link.TypeOf != InternalLink {
+		return false
+	}
+	// the same as in the validation, the anchor and the query aren't the part of the file name
+	filePath := strings.Split(strings.Split(link.AbsPath, "#")[0], "?")[0]
+	return d.removed[absPath("", filePath)]
+}
+
+// absPath returns the absolute path of the file relative to the directory
+func absPath(dir, filePath string) string {
+	result, err := filepath.Abs(filepath.Join(dir, filepath.FromSlash(filePath)))
+	if err != nil {
+		return filepath.Join(dir, filePath)
+	}
+	return result
+}
+
+// git runs the git command in the directory and returns its output
+func git(dir string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "git %s: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
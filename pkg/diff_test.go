@@ -0,0 +1,149 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-incubator/milv/cli"
+)
+
+func TestDiff(t *testing.T) {
+	root := t.TempDir()
+	write := func(filePath, content string) {
+		fullPath := filepath.Join(root, filePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+	run := func(args ...string) {
+		_, err := git(root, append([]string{"-c", "user.name=milv", "-c", "user.email=milv@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+		require.NoError(t, err)
+	}
+
+	run("init", "-q")
+	write("docs/guide.md", "# Guide\n\n[Setup](setup.md)\n[Old](old.md)\n")
+	write("docs/setup.md", "# Setup\n\n[Guide](guide.md)\n")
+	write("docs/old.md", "# Old\n\nThe old page, which is moved to another directory.\n")
+	write("docs/gone.md", "# Gone\n")
+	write("docs/index.md", "# Index\n\n[Gone](gone.md)\n[Setup](setup.md#setup)\n")
+	write("README.md", "# README\n\n[Guide](docs/guide.md)\n")
+	run("add", "-A")
+	run("commit", "-q", "-m", "Base")
+	run("tag", "base")
+
+	write("docs/guide.md", "# Guide\n\n[Setup](setup.md)\n[Install](install.md)\n[Old](old.md)\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "docs/archive"), 0755))
+	run("mv", "docs/old.md", "docs/archive/old.md")
+	run("rm", "-q", "docs/gone.md")
+	run("add", "-A")
+	run("commit", "-q", "-m", "Changes")
+
+	linksOf := func(files Files) map[string][]string {
+		result := map[string][]string{}
+		for _, file := range files {
+			relPath, err := filepath.Rel(root, file.RelPath)
+			require.NoError(t, err)
+			result[relPath] = []string{}
+			for _, link := range file.ExtractLinks().Links {
+				result[relPath] = append(result[relPath], link.RelPath)
+			}
+		}
+		return result
+	}
+	filePaths := []string{
+		filepath.Join(root, "README.md"),
+		filepath.Join(root, "docs/archive/old.md"),
+		filepath.Join(root, "docs/guide.md"),
+		filepath.Join(root, "docs/index.md"),
+		filepath.Join(root, "docs/setup.md"),
+	}
+
+	t.Run("Changes", func(t *testing.T) {
+		//WHEN
+		diff, err := NewDiff(root, "base")
+
+		//THEN
+		require.NoError(t, err)
+		assert.Equal(t, map[int]bool{4: true}, diff.added[filepath.Join(root, "docs/guide.md")])
+		assert.True(t, diff.changed(filepath.Join(root, "docs/archive/old.md")))
+		assert.True(t, diff.renamed[filepath.Join(root, "docs/archive/old.md")])
+		assert.False(t, diff.changed(filepath.Join(root, "docs/setup.md")))
+		assert.Equal(t, map[string]bool{
+			filepath.Join(root, "docs/old.md"):  true,
+			filepath.Join(root, "docs/gone.md"): true,
+		}, diff.removed)
+	})
+
+	t.Run("Unknown Ref", func(t *testing.T) {
+		//WHEN
+		_, err := NewDiff(root, "unknown")
+
+		//THEN
+		assert.Error(t, err)
+	})
+
+	t.Run("Changed Files", func(t *testing.T) {
+		//GIVEN
+		config, err := NewConfig(cli.Commands{ConfigFile: "milv.config.yaml", BasePath: root, Since: "base"})
+		require.NoError(t, err)
+
+		//WHEN
+		files, err := NewFiles(filePaths, config)
+
+		//THEN
+		require.NoError(t, err)
+		expected := map[string][]string{
+			"docs/archive/old.md": {},
+			"docs/guide.md":       {"setup.md", "install.md", "old.md"},
+			"docs/index.md":       {"gone.md"},
+		}
+		assert.Equal(t, expected, linksOf(files))
+	})
+
+	t.Run("Added Lines Only", func(t *testing.T) {
+		//GIVEN
+		config, err := NewConfig(cli.Commands{ConfigFile: "milv.config.yaml", BasePath: root, Since: "base", AddedLinesOnly: true})
+		require.NoError(t, err)
+
+		//WHEN
+		files, err := NewFiles(filePaths, config)
+
+		//THEN
+		require.NoError(t, err)
+		expected := map[string][]string{
+			"docs/archive/old.md": {},
+			"docs/guide.md":       {"install.md", "old.md"},
+			"docs/index.md":       {"gone.md"},
+		}
+		assert.Equal(t, expected, linksOf(files))
+	})
+
+	t.Run("Touched Files And Names With Spaces", func(t *testing.T) {
+		//GIVEN
+		write("docs/my guide.md", "# My guide\n\n[Setup](setup.md)\n")
+		run("add", "-A")
+		run("commit", "-q", "-m", "Spaces")
+		touched := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(root, "docs/setup.md"), touched, touched))
+
+		//WHEN
+		diff, err := NewDiff(root, "base")
+
+		//THEN
+		require.NoError(t, err)
+		assert.False(t, diff.changed(filepath.Join(root, "docs/setup.md")))
+		assert.Equal(t, map[int]bool{1: true, 2: true, 3: true}, diff.added[filepath.Join(root, "docs/my guide.md")])
+	})
+
+	t.Run("Added Lines Only Without Since", func(t *testing.T) {
+		//WHEN
+		_, err := NewConfig(cli.Commands{ConfigFile: "milv.config.yaml", BasePath: root, AddedLinesOnly: true})
+
+		//THEN
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,173 @@
+package pkg
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const diskCacheVersion = 1
+
+type CacheConfig struct {
+	Disabled   bool          `yaml:"disabled"`
+	File       string        `yaml:"file"`
+	SuccessTTL time.Duration `yaml:"success-ttl"`
+	FailureTTL time.Duration `yaml:"failure-ttl"`
+	Clear      bool          `yaml:"-"`
+}
+
+// DiskCache stores results of external links checks between runs
+type DiskCache struct {
+	path       string
+	successTTL time.Duration
+	failureTTL time.Duration
+	mu         sync.Mutex
+	entries    map[string]diskCacheEntry
+}
+
+type diskCacheFile struct {
+	Version int                       `json:"version"`
+	Entries map[string]diskCacheEntry `json:"entries"`
+}
+
+type diskCacheEntry struct {
+	Result     LinkResult  `json:"result"`
+	StatusCode int         `json:"statusCode"`
+	Message    string      `json:"message,omitempty"`
+	Anchors    []string    `json:"anchors,omitempty"`
+	Redirects  []Redirect  `json:"redirects,omitempty"`
+	Failure    FailureKind `json:"failure,omitempty"`
+	CheckedAt  time.Time   `json:"checkedAt"`
+}
+
+// LoadDiskCache reads the cache file. A missing file results in an empty cache.
+func LoadDiskCache(config CacheConfig) (*DiskCache, error) {
+	cache := newDiskCache(config)
+
+	if config.Clear {
+		if err := os.Remove(config.File); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return cache, nil
+	}
+
+	content, err := ioutil.ReadFile(config.File)
+	if os.IsNotExist(err) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	file := diskCacheFile{}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, err
+	}
+
+	// results saved by another version may not be compatible, so they're checked again
+	if file.Version == diskCacheVersion && file.Entries != nil {
+		cache.entries = file.Entries
+	}
+	return cache, nil
+}
+
+func newDiskCache(config CacheConfig) *DiskCache {
+	return &DiskCache{
+		path:       config.File,
+		successTTL: config.SuccessTTL,
+		failureTTL: config.FailureTTL,
+		entries:    map[string]diskCacheEntry{},
+	}
+}
+
+// Get returns the result of the URL if it was checked within its TTL
+func (c *DiskCache) Get(key string) (checkResult, bool) {
+	if c == nil {
+		return checkResult{}, false
+	}
+
+	c.mu.Lock()
+	entry, found := c.entries[key]
+	c.mu.Unlock()
+	if !found || time.Since(entry.CheckedAt) > c.ttl(entry.Result.Status) {
+		return checkResult{}, false
+	}
+
+	return checkResult{
+		StatusCode: entry.StatusCode,
+		Message:    entry.Message,
+		Anchors:    entry.Anchors,
+		Redirects:  entry.Redirects,
+		Failure:    entry.Failure,
+	}, true
+}
+
+func (c *DiskCache) Put(key string, result checkResult) {
+	if c == nil {
+		return
+	}
+
+	linkResult := result.linkResult("", true, false)
+	if c.ttl(linkResult.Status) <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = diskCacheEntry{
+		Result:     linkResult,
+		StatusCode: result.StatusCode,
+		Message:    result.Message,
+		Anchors:    result.Anchors,
+		Redirects:  result.Redirects,
+		Failure:    result.Failure,
+		CheckedAt:  time.Now(),
+	}
+}
+
+// Save writes entries which are still within their TTL to the cache file
+func (c *DiskCache) Save() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	file := diskCacheFile{Version: diskCacheVersion, Entries: map[string]diskCacheEntry{}}
+	for key, entry := range c.entries {
+		if time.Since(entry.CheckedAt) <= c.ttl(entry.Result.Status) {
+			file.Entries[key] = entry
+		}
+	}
+	c.mu.Unlock()
+
+	content, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// write to the temporary file first, so the interrupted run doesn't corrupt the cache
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path))
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+func (c *DiskCache) ttl(success bool) time.Duration {
+	if success {
+		return c.successTTL
+	}
+	return c.failureTTL
+}
@@ -0,0 +1,151 @@
+package pkg
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCache(t *testing.T) {
+	t.Run("Results are saved and loaded", func(t *testing.T) {
+		//GIVEN
+		config := CacheConfig{
+			File:       filepath.Join(t.TempDir(), ".milv-cache.json"),
+			SuccessTTL: time.Hour,
+			FailureTTL: time.Hour,
+		}
+		cache, err := LoadDiskCache(config)
+		require.NoError(t, err)
+
+		success := checkResult{StatusCode: 200, Message: "200 OK", Anchors: []string{"overview"}}
+		failure := checkResult{StatusCode: 404, Message: "404 Not Found"}
+
+		//WHEN
+		cache.Put("https://github.com", success)
+		cache.Put("https://github.com/404", failure)
+		require.NoError(t, cache.Save())
+
+		loaded, err := LoadDiskCache(config)
+		require.NoError(t, err)
+
+		//THEN
+		result, found := loaded.Get("https://github.com")
+		require.True(t, found)
+		assert.Equal(t, success, result)
+
+		result, found = loaded.Get("https://github.com/404")
+		require.True(t, found)
+		assert.Equal(t, failure, result)
+
+		_, found = loaded.Get("https://twitter.com")
+		assert.False(t, found)
+	})
+
+	t.Run("Expired results are ignored", func(t *testing.T) {
+		//GIVEN
+		cache := newDiskCache(CacheConfig{SuccessTTL: time.Hour, FailureTTL: time.Minute})
+		cache.entries["https://github.com"] = diskCacheEntry{
+			Result:     LinkResult{Status: true},
+			StatusCode: 200,
+			CheckedAt:  time.Now().Add(-30 * time.Minute),
+		}
+		cache.entries["https://github.com/404"] = diskCacheEntry{
+			Result:     LinkResult{Status: false, Message: "404 Not Found", Kind: HTTPStatus},
+			StatusCode: 404,
+			CheckedAt:  time.Now().Add(-30 * time.Minute),
+		}
+
+		//WHEN
+		_, successFound := cache.Get("https://github.com")
+		_, failureFound := cache.Get("https://github.com/404")
+
+		//THEN
+		assert.True(t, successFound)
+		assert.False(t, failureFound)
+	})
+
+	t.Run("Failures aren't cached without TTL", func(t *testing.T) {
+		//GIVEN
+		cache := newDiskCache(CacheConfig{SuccessTTL: time.Hour})
+
+		//WHEN
+		cache.Put("https://github.com/404", checkResult{StatusCode: 404, Message: "404 Not Found"})
+		cache.Put("https://github.com", checkResult{Message: "no such host"})
+
+		//THEN
+		assert.Empty(t, cache.entries)
+	})
+
+	t.Run("Clear removes the file", func(t *testing.T) {
+		//GIVEN
+		config := CacheConfig{
+			File:       filepath.Join(t.TempDir(), ".milv-cache.json"),
+			SuccessTTL: time.Hour,
+		}
+		cache, err := LoadDiskCache(config)
+		require.NoError(t, err)
+		cache.Put("https://github.com", checkResult{StatusCode: 200, Message: "200 OK"})
+		require.NoError(t, cache.Save())
+
+		//WHEN
+		config.Clear = true
+		cleared, err := LoadDiskCache(config)
+
+		//THEN
+		require.NoError(t, err)
+		_, found := cleared.Get("https://github.com")
+		assert.False(t, found)
+		assert.Error(t, fileExists(config.File))
+	})
+
+	t.Run("Corrupted file returns error", func(t *testing.T) {
+		//GIVEN
+		file := filepath.Join(t.TempDir(), ".milv-cache.json")
+		require.NoError(t, ioutil.WriteFile(file, []byte("{not json"), 0644))
+
+		//WHEN
+		_, err := LoadDiskCache(CacheConfig{File: file})
+
+		//THEN
+		assert.Error(t, err)
+	})
+
+	t.Run("Cached results skip requests", func(t *testing.T) {
+		//GIVEN
+		requests := 0
+		svc := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			requests++
+			writer.WriteHeader(http.StatusOK)
+		}))
+		defer svc.Close()
+
+		config := CacheConfig{
+			File:       filepath.Join(t.TempDir(), ".milv-cache.json"),
+			SuccessTTL: time.Hour,
+		}
+		links := []Link{{AbsPath: svc.URL + "/docs", TypeOf: ExternalLink}}
+
+		firstRun, err := LoadDiskCache(config)
+		require.NoError(t, err)
+		v := NewValidator(http.Client{}, &waitMock{})
+		v.cache = NewResultCache(firstRun)
+		v.Links(links)
+		require.NoError(t, v.cache.Save())
+
+		//WHEN
+		secondRun, err := LoadDiskCache(config)
+		require.NoError(t, err)
+		v.cache = NewResultCache(secondRun)
+		result := v.Links(links)
+
+		//THEN
+		assert.Equal(t, 1, requests)
+		assert.True(t, result[0].Result.Status)
+	})
+}
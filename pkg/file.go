@@ -2,13 +2,40 @@ package pkg
 
 import (
 	"net/http"
+	"os"
 	"path/filepath"
-	"regexp"
+	"strings"
 
 	"github.com/pkg/errors"
 )
 
-type Headers []string
+// HeaderOrigin tells how the header is written in the markdown
+type HeaderOrigin string
+
+const (
+	// ATXHeader is the heading starting with #
+	ATXHeader HeaderOrigin = "atx"
+	// SetextHeader is the heading underlined with = or -
+	SetextHeader HeaderOrigin = "setext"
+	// HTMLHeader is the HTML heading, such as <h2>
+	HTMLHeader HeaderOrigin = "html"
+	// AnchorHeader is the HTML element with the id, the anchor with the name,
+	// or the explicit target of reStructuredText or AsciiDoc
+	AnchorHeader HeaderOrigin = "anchor"
+	// SectionHeader is the section title of reStructuredText or AsciiDoc, its ID is generated the way of the format
+	SectionHeader HeaderOrigin = "section"
+)
+
+// Header is the heading of the document or the HTML element which can be linked with the anchor
+type Header struct {
+	Text string
+	// ID is the explicit anchor, such as {#custom-id} of the heading or the id of the HTML element
+	ID     string
+	Origin HeaderOrigin
+	Line   int
+}
+
+type Headers []Header
 
 type File struct {
 	RelPath string `yaml:"path"`
@@ -20,13 +47,16 @@ type File struct {
 	Status  bool
 	Config  *FileConfig `yaml:"config"`
 	Stats   *FileStats
-	parser  *Parser
+	parser  SourceParser
 	valid   *Validator
 }
 
 func NewFile(filePath string, fileLinks Links, config FileConfig) (*File, error) {
-	if match, _ := regexp.MatchString(`.md$`, filePath); !match {
-		return nil, errors.New("The specified file isn't a markdown file")
+	parser := NewParser(config)
+	source, found := NewSourceParser(filePath, parser)
+	if !found {
+		return nil, errors.Errorf("The format of the specified file isn't supported, supported extensions: %s",
+			strings.Join(SourceExtensions(), ", "))
 	}
 
 	absPath, _ := filepath.Abs(filePath)
@@ -40,6 +70,14 @@ func NewFile(filePath string, fileLinks Links, config FileConfig) (*File, error)
 
 	client := http.Client{}
 	waiter := NewWaiter(config.Backoff)
+	valid := NewValidator(client, waiter)
+	valid.pool = newWorkerPool(config.Concurrency)
+	valid.parser = parser
+	valid.dirPath = filepath.Dir(filePath)
+	valid.slugger, err = NewSlugger(config.SlugStyle)
+	if err != nil {
+		return nil, err
+	}
 
 	return &File{
 		RelPath: filePath,
@@ -48,8 +86,8 @@ func NewFile(filePath string, fileLinks Links, config FileConfig) (*File, error)
 		Content: content,
 		Links:   fileLinks,
 		Config:  &config,
-		parser:  &Parser{},
-		valid:   NewValidator(client, waiter),
+		parser:  source,
+		valid:   valid,
 	}, nil
 }
 
@@ -67,17 +105,11 @@ func (f *File) ExtractLinks() *File {
 		internalLinksToIgnore = f.Config.InternalLinksToIgnore
 	}
 
-	content := f.Content
-	if f.Config != nil && f.Config.AllowCodeBlocks != nil && !*f.Config.AllowCodeBlocks {
-		content = removeCodeBlocks(content)
-	}
-
 	basePath := ""
 	if f.Config != nil {
 		basePath = f.Config.BasePath
 	}
-	f.Links = f.parser.
-		Links(basePath, content, f.DirPath).
+	f.Links = skipSuppressedLinks(f.parser.Links(basePath, f.Content, f.DirPath), f.parser, f.Content).
 		AppendConfig(f).
 		RemoveIgnoredLinks(externalLinksToIgnore, internalLinksToIgnore).
 		Filter(func(link Link) bool {
@@ -89,6 +121,10 @@ func (f *File) ExtractLinks() *File {
 				return false
 			}
 
+			if f.Config != nil && f.Config.diff != nil {
+				return f.Config.diff.keeps(f.RelPath, link, f.Config.addedLinesOnly)
+			}
+
 			return true
 		})
 	return f
@@ -110,8 +146,10 @@ func (f *File) ExtractStats() *File {
 	return f
 }
 
+// WriteStats writes every link of the file with its status to the standard error, like other logs,
+// so the standard output is only for the report
 func (f *File) WriteStats() *File {
-	writeStats(f)
+	writeStats(os.Stderr, f)
 	return f
 }
 
@@ -20,6 +20,75 @@ func TestFile(t *testing.T) {
 		assert.Error(t, err, "The specified file isn't a markdown file")
 	})
 
+	t.Run("Slug Style", func(t *testing.T) {
+		//GIVEN
+		github, err := NewFile("test-markdowns/custom_anchors.md", links, FileConfig{SlugStyle: GitHubSlugStyle})
+		require.NoError(t, err)
+		hugo, err := NewFile("test-markdowns/custom_anchors.md", links, FileConfig{SlugStyle: HugoSlugStyle})
+		require.NoError(t, err)
+
+		//WHEN
+		github.Run()
+		hugo.Run()
+
+		//THEN
+		statuses := func(file *File) map[string]bool {
+			result := map[string]bool{}
+			for _, link := range file.Links {
+				result[link.RelPath] = link.Result.Status
+			}
+			return result
+		}
+		assert.Equal(t, map[string]bool{
+			"#setup":              false,
+			"#installation-setup": true,
+			"#legacy-anchor":      true,
+			"#box":                true,
+			"#overview":           true,
+			"#html-heading":       true,
+			"#config":             true,
+		}, statuses(github))
+		assert.Equal(t, map[string]bool{
+			"#setup":              true,
+			"#installation-setup": false,
+			"#legacy-anchor":      true,
+			"#box":                true,
+			"#overview":           true,
+			"#html-heading":       true,
+			"#config":             true,
+		}, statuses(hugo))
+	})
+
+	t.Run("Unknown Slug Style", func(t *testing.T) {
+		_, err := NewFile("test-markdowns/custom_anchors.md", links, FileConfig{SlugStyle: "unknown"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Source Formats", func(t *testing.T) {
+		for _, filePath := range []string{"test-markdowns/formats/guide.rst", "test-markdowns/formats/guide.adoc", "test-markdowns/formats/page.mdx"} {
+			t.Run(filePath, func(t *testing.T) {
+				//GIVEN
+				ignoreExternal := true
+				file, err := NewFile(filePath, links, FileConfig{IgnoreExternal: &ignoreExternal})
+				require.NoError(t, err)
+
+				//WHEN
+				file.Run()
+
+				//THEN
+				assert.NotEmpty(t, file.Links)
+				for _, link := range file.Links {
+					assert.True(t, link.Result.Status, link.RelPath)
+				}
+			})
+		}
+	})
+
+	t.Run("Unsupported Format", func(t *testing.T) {
+		_, err := NewFile("test-markdowns/formats/notes.txt", links, FileConfig{})
+		assert.Error(t, err)
+	})
+
 	t.Run("Extract Links", func(t *testing.T) {
 		file, err := NewFile("test-markdowns/external_links.md", links, FileConfig{})
 		require.NoError(t, err)
@@ -29,16 +98,25 @@ func TestFile(t *testing.T) {
 				AbsPath: "https://twitter.com",
 				Config:  &LinkConfig{},
 				TypeOf:  ExternalLink,
+				Line:    7,
+				Column:  19,
+				Text:    "[Twitter](https://twitter.com)",
 			},
 			Link{
 				AbsPath: "https://github.com",
 				Config:  &LinkConfig{},
 				TypeOf:  ExternalLink,
+				Line:    9,
+				Column:  18,
+				Text:    "[Github](https://github.com)",
 			},
 			Link{
 				AbsPath: "https://httpbin.org/status/404",
 				Config:  &LinkConfig{},
 				TypeOf:  ExternalLink,
+				Line:    11,
+				Column:  29,
+				Text:    "[Link](https://httpbin.org/status/404)",
 			},
 		}
 
@@ -51,13 +129,13 @@ func TestFile(t *testing.T) {
 		require.NoError(t, err)
 
 		expected := Headers{
-			"First Header",
-			"Second Header",
-			"Third Header",
-			"Header with link",
-			"Header with block",
-			"Very strange header really people create headers look like this",
-			"Links",
+			Header{Text: "First Header", Origin: ATXHeader, Line: 1},
+			Header{Text: "Second Header", Origin: ATXHeader, Line: 5},
+			Header{Text: "Third Header", Origin: ATXHeader, Line: 9},
+			Header{Text: "Header with link", Origin: ATXHeader, Line: 13},
+			Header{Text: "Header with block", Origin: ATXHeader, Line: 17},
+			Header{Text: "Very strange header (really, people create headers look like this)", Origin: ATXHeader, Line: 21},
+			Header{Text: "Links", Origin: ATXHeader, Line: 25},
 		}
 
 		file.ExtractHeaders()
@@ -73,6 +151,9 @@ func TestFile(t *testing.T) {
 				AbsPath: "https://twitter.com",
 				Config:  &LinkConfig{},
 				TypeOf:  ExternalLink,
+				Line:    7,
+				Column:  19,
+				Text:    "[Twitter](https://twitter.com)",
 				Result: LinkResult{
 					Status: true,
 				},
@@ -81,6 +162,9 @@ func TestFile(t *testing.T) {
 				AbsPath: "https://github.com",
 				Config:  &LinkConfig{},
 				TypeOf:  ExternalLink,
+				Line:    9,
+				Column:  18,
+				Text:    "[Github](https://github.com)",
 				Result: LinkResult{
 					Status: true,
 				},
@@ -89,15 +173,20 @@ func TestFile(t *testing.T) {
 				AbsPath: "https://httpbin.org/status/404",
 				Config:  &LinkConfig{},
 				TypeOf:  ExternalLink,
+				Line:    11,
+				Column:  29,
+				Text:    "[Link](https://httpbin.org/status/404)",
 				Result: LinkResult{
 					Status:  false,
 					Message: "404 Not Found",
+					Kind:    HTTPStatus,
 				},
 			},
 		}
 
 		file.ExtractLinks()
 		file.ValidateLinks()
+		clearRedirects(file.Links)
 		assert.Equal(t, expected, file.Links)
 	})
 }
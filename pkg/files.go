@@ -1,16 +1,38 @@
 package pkg
 
+import (
+	"log"
+	"os"
+)
+
 type Files []*File
 
 func NewFiles(filePaths []string, config *Config) (Files, error) {
 	var files Files
 
+	// all files share one pool, limiter and cache, so the limits apply to the whole run
+	// and every external URL is requested only once
+	pool := newWorkerPool(config.Concurrency)
+	limiter := NewHostLimiter(config.RateLimit)
+	cache := NewResultCache(loadDiskCache(config.Cache))
+	renames := NewRenames(config.rootDir())
+
 	filePaths = removeIgnoredFiles(filePaths, config.FilesToIgnore)
 	for _, filePath := range filePaths {
 		file, err := NewFile(filePath, NewLinks(filePath, config), NewFileConfig(filePath, config))
 		if err != nil {
 			return Files{}, err
 		}
+		if config.diff != nil && !config.diff.selects(file) {
+			continue
+		}
+		file.valid.pool = pool
+		file.valid.limiter = limiter
+		file.valid.cache = cache
+		file.valid.renames = renames
+		file.valid.fix = config.Fix
+		file.valid.redirects = config.Redirects
+		file.valid.severities = config.Severities
 		files = append(files, file)
 	}
 
@@ -18,14 +40,61 @@ func NewFiles(filePaths []string, config *Config) (Files, error) {
 }
 
 func (f Files) Run(verbose bool) {
-	for _, file := range f {
-		file.Run()
-		if verbose {
+	newWorkerPool(f.concurrency()).Run(len(f), func(i int) {
+		f[i].Run()
+	})
+
+	if verbose {
+		for _, file := range f {
 			file.WriteStats()
 		}
 	}
+
+	// the cache is shared by all files
+	if len(f) > 0 {
+		if err := f[0].valid.cache.Save(); err != nil {
+			log.Printf("Error while saving cache: %+v", err)
+		}
+	}
 }
 
 func (f Files) Summary() bool {
-	return summaryOfFiles(f)
+	return summaryOfFiles(os.Stdout, f, ErrorSeverity)
+}
+
+// Failed returns true if any link in the files is broken
+func (f Files) Failed() bool {
+	return f.FailedOn(ErrorSeverity)
+}
+
+// FailedOn returns true if any link in the files has the problem of the severity or a more important one
+func (f Files) FailedOn(severity Severity) bool {
+	for _, file := range f {
+		for _, link := range file.Links {
+			if link.Result.fails(severity) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f Files) concurrency() int {
+	if len(f) == 0 || f[0].Config == nil {
+		return 1
+	}
+	return f[0].Config.Concurrency
+}
+
+func loadDiskCache(config CacheConfig) *DiskCache {
+	if config.Disabled || config.File == "" {
+		return nil
+	}
+
+	cache, err := LoadDiskCache(config)
+	if err != nil {
+		log.Printf("Error while loading cache, links will be checked again: %+v", err)
+		return newDiskCache(config)
+	}
+	return cache
 }
@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const excludePatternPrefix = "!"
+
+// DefaultFilesToCheck selects all markdown files in the base path
+var DefaultFilesToCheck = []string{"**/*.md"}
+
+// FindFiles walks the base path and returns files which match the files-to-check patterns and aren't ignored
+// by files-to-ignore, .gitignore or .milvignore files. Paths are relative to the working directory.
+func FindFiles(config *Config) ([]string, error) {
+	root := config.rootDir()
+	includes, excludes := filePatterns(config.FilesToCheck)
+	ignores := config.ignores()
+
+	var filePaths []string
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(root, filePath)
+		if err != nil || relPath == "." {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		if entry.IsDir() {
+			if entry.Name() == ".git" || ignores.ignored(relPath, true) ||
+				isPathIgnored(relPath, true, config.FilesToIgnore) || matchesAny(excludes, relPath+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if ignores.ignored(relPath, false) || isFileIgnored(relPath, config.FilesToIgnore) ||
+			!matchesAny(includes, relPath) || matchesAny(excludes, relPath) {
+			return nil
+		}
+		if root == "." {
+			filePaths = append(filePaths, "./"+relPath)
+		} else {
+			filePaths = append(filePaths, filepath.Join(root, relPath))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error while searching files in %s", root)
+	}
+	return filePaths, nil
+}
+
+// filePatterns splits glob patterns into patterns of files to check and patterns of files to skip,
+// which start with !. Without patterns of files to check, all markdown files are checked.
+func filePatterns(patterns []string) (includes, excludes []*regexp.Regexp) {
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		exclude := strings.HasPrefix(pattern, excludePatternPrefix)
+		glob := strings.TrimPrefix(strings.TrimPrefix(pattern, excludePatternPrefix), "./")
+		re := regexp.MustCompile(globToRegex(glob))
+		if exclude {
+			excludes = append(excludes, re)
+		} else {
+			includes = append(includes, re)
+		}
+	}
+	if len(includes) == 0 {
+		for _, pattern := range DefaultFilesToCheck {
+			includes = append(includes, regexp.MustCompile(globToRegex(pattern)))
+		}
+	}
+	return includes, excludes
+}
+
+func matchesAny(patterns []*regexp.Regexp, relPath string) bool {
+	for _, re := range patterns {
+		if re.MatchString(relPath) {
+			return true
+		}
+	}
+	return false
+}
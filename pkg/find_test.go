@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFiles(t *testing.T) {
+	root := t.TempDir()
+	for filePath, content := range map[string]string{
+		".gitignore":                   "build/\n*.draft.md\n!keep.draft.md\n",
+		"README.md":                    "",
+		"notes.txt":                    "",
+		"docs/guide.md":                "",
+		"docs/todo.draft.md":           "",
+		"docs/keep.draft.md":           "",
+		"vendor/lib/lib.md":            "",
+		"build/output.md":              "",
+		"website/content.md":           "",
+		"website/src/page.md":          "",
+		"web/.gitignore":               "node_modules/\n",
+		"web/node_modules/a/README.md": "",
+		"web/index.md":                 "",
+		".milvignore":                  "generated/\nlink: web/*.md localhost\n",
+		"docs/generated/api.md":        "",
+	} {
+		fullPath := filepath.Join(root, filePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+
+	t.Run("Default Patterns", func(t *testing.T) {
+		//GIVEN
+		config := &Config{BasePath: root}
+
+		//WHEN
+		result, err := FindFiles(config)
+
+		//THEN
+		require.NoError(t, err)
+		expected := []string{
+			filepath.Join(root, "README.md"),
+			filepath.Join(root, "docs/guide.md"),
+			filepath.Join(root, "docs/keep.draft.md"),
+			filepath.Join(root, "vendor/lib/lib.md"),
+			filepath.Join(root, "web/index.md"),
+			filepath.Join(root, "website/content.md"),
+			filepath.Join(root, "website/src/page.md"),
+		}
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("Without Gitignore", func(t *testing.T) {
+		//GIVEN
+		config := &Config{BasePath: root, NoGitignore: true, FilesToCheck: []string{"**/*.md", "!vendor/**", "!website/**"}}
+
+		//WHEN
+		result, err := FindFiles(config)
+
+		//THEN
+		require.NoError(t, err)
+		expected := []string{
+			filepath.Join(root, "README.md"),
+			filepath.Join(root, "build/output.md"),
+			filepath.Join(root, "docs/guide.md"),
+			filepath.Join(root, "docs/keep.draft.md"),
+			filepath.Join(root, "docs/todo.draft.md"),
+			filepath.Join(root, "web/index.md"),
+			filepath.Join(root, "web/node_modules/a/README.md"),
+		}
+		assert.Equal(t, expected, result)
+	})
+
+	t.Run("Files To Check And Files To Ignore", func(t *testing.T) {
+		//GIVEN
+		config := &Config{
+			BasePath:      root,
+			FilesToCheck:  []string{"**/*.md", "!**/vendor/**", "!docs/keep.*"},
+			FilesToIgnore: []string{"./website/src", "README.md"},
+		}
+
+		//WHEN
+		result, err := FindFiles(config)
+
+		//THEN
+		require.NoError(t, err)
+		expected := []string{
+			filepath.Join(root, "docs/guide.md"),
+			filepath.Join(root, "web/index.md"),
+			filepath.Join(root, "website/content.md"),
+		}
+		assert.Equal(t, expected, result)
+	})
+
+}
@@ -0,0 +1,228 @@
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Fix rewrites links which have fixes in files, and logs the number of fixed links in every file
+func (f Files) Fix() error {
+	for _, file := range f {
+		fixed, err := file.Fix()
+		if err != nil {
+			return err
+		}
+		if fixed > 0 {
+			log.Printf("Fixed %d links in %s", fixed, file.RelPath)
+		}
+	}
+	return nil
+}
+
+// WriteFixes writes fixes of links in files as the unified diff instead of rewriting files,
+// so they can be reviewed or applied with git apply
+func (f Files) WriteFixes(w io.Writer) error {
+	for _, file := range f {
+		content, fixed := fixLinks(file.Content, file.Links)
+		if fixed == 0 {
+			continue
+		}
+		if _, err := io.WriteString(w, unifiedDiff(file.RelPath, file.Content, content)); err != nil {
+			return errors.Wrap(err, "Error while writing fixes")
+		}
+	}
+	return nil
+}
+
+// Fix rewrites links which have fixes in the file and returns the number of fixed links
+func (f *File) Fix() (int, error) {
+	content, fixed := fixLinks(f.Content, f.Links)
+	if fixed == 0 {
+		return 0, nil
+	}
+
+	info, err := os.Stat(f.AbsPath)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Error while fixing links in %s", f.RelPath)
+	}
+	if err := os.WriteFile(f.AbsPath, []byte(content), info.Mode().Perm()); err != nil {
+		return 0, errors.Wrapf(err, "Error while fixing links in %s", f.RelPath)
+	}
+	f.Content = content
+	return fixed, nil
+}
+
+// fixLinks replaces the link of every link with the fix. The link is found by its text at its line and column,
+// so other occurrences of the same link in the content don't change, and the rest of the content is kept as it is.
+func fixLinks(content string, links Links) (string, int) {
+	var fixable Links
+	for _, link := range links {
+		if link.Result.Fix != "" && !link.Result.Skipped && link.Text != "" {
+			fixable = append(fixable, link)
+		}
+	}
+	// links are replaced from the end of the content, so offsets of other links don't change
+	sort.SliceStable(fixable, func(i, j int) bool {
+		if fixable[i].Line != fixable[j].Line {
+			return fixable[i].Line > fixable[j].Line
+		}
+		return fixable[i].Column > fixable[j].Column
+	})
+
+	fixed := 0
+	lineOffsets := lineOffsets(content)
+	for _, link := range fixable {
+		if link.Line < 1 || link.Line > len(lineOffsets) {
+			continue
+		}
+		start := lineOffsets[link.Line-1] + columnOffset(content[lineOffsets[link.Line-1]:], link.Column)
+		if !strings.HasPrefix(content[start:], link.Text) {
+			continue
+		}
+		written := writtenLink(link)
+		destination := destinationStart(link.Text)
+		i := strings.Index(link.Text[destination:], written)
+		if written == "" || i < 0 {
+			continue
+		}
+		start += destination + i
+		content = content[:start] + link.Result.Fix + content[start+len(written):]
+		fixed++
+	}
+	return content, fixed
+}
+
+// writtenLink returns the link as it is written in the file: the URL of external links and the path of others
+func writtenLink(link Link) string {
+	if link.TypeOf == ExternalLink {
+		return link.AbsPath
+	}
+	return link.RelPath
+}
+
+// destinationStart returns the byte offset in the text of the link where its destination can start,
+// so the label, which can be the same as the destination, isn't replaced: the destination follows
+// the label of markdown links, such as [label](destination) or [label]: destination,
+// and it's between < and > in reStructuredText links, such as `label <destination>`_
+func destinationStart(text string) int {
+	switch {
+	case strings.HasPrefix(text, "[") || strings.HasPrefix(text, "!["):
+		depth := 0
+		for i := 0; i < len(text); i++ {
+			switch text[i] {
+			case '\\':
+				i++
+			case '[':
+				depth++
+			case ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+	case strings.HasPrefix(text, "`"):
+		if i := strings.LastIndex(text, "<"); i >= 0 {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// lineOffsets returns byte offsets where lines of the content start
+func lineOffsets(content string) []int {
+	offsets := []int{0}
+	for i, r := range content {
+		if r == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// columnOffset returns the byte offset of the column, columns are counted in characters starting from 1
+func columnOffset(line string, column int) int {
+	offset := 0
+	for i := 1; i < column && offset < len(line); i++ {
+		_, size := utf8.DecodeRuneInString(line[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// diffContext is the number of unchanged lines around changed lines in the unified diff
+const diffContext = 3
+
+// unifiedDiff returns the unified diff of the fixed content. Fixes change links inside lines,
+// so both contents have the same number of lines, and changed lines are compared one by one.
+func unifiedDiff(filePath, before, after string) string {
+	oldLines, newLines := diffLines(before), diffLines(after)
+	var changed []int
+	for i := range oldLines {
+		if oldLines[i] != newLines[i] {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		return ""
+	}
+
+	name := filepath.ToSlash(strings.TrimPrefix(filePath, "./"))
+	var diff strings.Builder
+	fmt.Fprintf(&diff, "--- a/%s\n+++ b/%s\n", name, name)
+	for first := 0; first < len(changed); {
+		// changed lines closer than twice the context are in the same hunk
+		last := first
+		for last+1 < len(changed) && changed[last+1]-changed[last] <= 2*diffContext {
+			last++
+		}
+		start, end := changed[first]-diffContext, changed[last]+diffContext+1
+		if start < 0 {
+			start = 0
+		}
+		if end > len(oldLines) {
+			end = len(oldLines)
+		}
+		fmt.Fprintf(&diff, "@@ -%d,%d +%d,%d @@\n", start+1, end-start, start+1, end-start)
+		for i := start; i < end; {
+			if oldLines[i] == newLines[i] {
+				diff.WriteString(" " + oldLines[i])
+				i++
+				continue
+			}
+			// the block of changed lines is written as removed lines followed by added lines
+			block := i
+			for block < end && oldLines[block] != newLines[block] {
+				block++
+			}
+			for _, line := range oldLines[i:block] {
+				diff.WriteString("-" + line)
+			}
+			for _, line := range newLines[i:block] {
+				diff.WriteString("+" + line)
+			}
+			i = block
+		}
+		first = last + 1
+	}
+	return diff.String()
+}
+
+// diffLines splits the content into lines with their line endings. The last line without the line ending
+// is marked the way of the unified diff.
+func diffLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n\\ No newline at end of file\n"
+	return lines
+}
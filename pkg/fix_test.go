@@ -0,0 +1,196 @@
+package pkg
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-incubator/milv/cli"
+)
+
+func TestFix(t *testing.T) {
+	t.Run("Fix Links", func(t *testing.T) {
+		//GIVEN
+		content := "# Zażółć\n\nSee [a](a.md) and [a](a.md#b).\n[a](a.md) is [valid](a.md)\n"
+		links := Links{
+			{RelPath: "a.md", Line: 3, Column: 5, Text: "[a](a.md)", Result: LinkResult{Fix: "docs/a.md"}},
+			{RelPath: "a.md#b", Line: 3, Column: 19, Text: "[a](a.md#b)", Result: LinkResult{Fix: "docs/a.md#b"}},
+			{RelPath: "a.md", Line: 4, Column: 14, Text: "[valid](a.md)", Result: LinkResult{Status: true, Skipped: true, Fix: "docs/a.md"}},
+			{RelPath: "a.md", Line: 4, Column: 1, Text: "[a](a.md)", Result: LinkResult{Fix: "docs/a.md"}},
+			{RelPath: "b.md", Line: 1, Column: 1, Text: "[b](b.md)", Result: LinkResult{Fix: "docs/b.md"}},
+		}
+
+		//WHEN
+		result, fixed := fixLinks(content, links)
+
+		//THEN
+		expected := "# Zażółć\n\nSee [a](docs/a.md) and [a](docs/a.md#b).\n[a](docs/a.md) is [valid](a.md)\n"
+		assert.Equal(t, expected, result)
+		assert.Equal(t, 3, fixed)
+	})
+
+	t.Run("Label Same As Link", func(t *testing.T) {
+		//GIVEN
+		content := "[#instalation](#instalation)\n[https://x/old](https://x/old) [[a.md]](a.md)\n`a.md <a.md>`_\n"
+		links := Links{
+			{RelPath: "#instalation", Line: 1, Column: 1, Text: "[#instalation](#instalation)", Result: LinkResult{Fix: "#installation"}},
+			{AbsPath: "https://x/old", TypeOf: ExternalLink, Line: 2, Column: 1, Text: "[https://x/old](https://x/old)", Result: LinkResult{Fix: "https://x/new"}},
+			{RelPath: "a.md", Line: 2, Column: 32, Text: "[[a.md]](a.md)", Result: LinkResult{Fix: "docs/a.md"}},
+			{RelPath: "a.md", Line: 3, Column: 1, Text: "`a.md <a.md>`_", Result: LinkResult{Fix: "docs/a.md"}},
+		}
+
+		//WHEN
+		result, fixed := fixLinks(content, links)
+
+		//THEN
+		expected := "[#instalation](#installation)\n[https://x/old](https://x/new) [[a.md]](docs/a.md)\n`a.md <docs/a.md>`_\n"
+		assert.Equal(t, expected, result)
+		assert.Equal(t, 4, fixed)
+	})
+
+	t.Run("Moved Files", func(t *testing.T) {
+		//GIVEN
+		root := t.TempDir()
+		write := func(filePath, content string) {
+			fullPath := filepath.Join(root, filePath)
+			require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+			require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+		}
+		run := func(args ...string) {
+			_, err := git(root, append([]string{"-c", "user.name=milv", "-c", "user.email=milv@example.com", "-c", "commit.gpgsign=false"}, args...)...)
+			require.NoError(t, err)
+		}
+
+		run("init", "-q")
+		write("docs/a.md", "# A\n\n## Usage\n")
+		write("docs/b.md", "# B\n")
+		run("add", "-A")
+		run("commit", "-q", "-m", "Base")
+		require.NoError(t, os.MkdirAll(filepath.Join(root, "docs/guide"), 0755))
+		run("mv", "docs/a.md", "docs/guide/a.md")
+		run("commit", "-q", "-m", "Move")
+		run("mv", "docs/guide/a.md", "docs/guide/intro.md")
+		run("rm", "-q", "docs/b.md")
+		run("commit", "-q", "-m", "Rename")
+		write("README.md", "# README\n\n[A](docs/a.md#usage) [A](/docs/a.md) [B](docs/b.md)\n")
+
+		config, err := NewConfig(cli.Commands{ConfigFile: "milv.config.yaml", BasePath: root, Fix: true})
+		require.NoError(t, err)
+		files, err := NewFiles([]string{filepath.Join(root, "README.md")}, config)
+		require.NoError(t, err)
+		// the git history is read only for links to missing files
+		require.Nil(t, files[0].valid.renames.moved)
+
+		//WHEN
+		files.Run(false)
+		err = files.Fix()
+
+		//THEN
+		require.NoError(t, err)
+		assert.Len(t, files[0].valid.renames.moved, 2)
+		links := files[0].Links
+		require.Len(t, links, 3)
+		assert.Equal(t, "The specified file doesn't exist, it was moved. Did you mean docs/guide/intro.md#usage?", links[0].Result.Message)
+		assert.Equal(t, "docs/guide/intro.md#usage", links[0].Result.Fix)
+		assert.Equal(t, "/docs/guide/intro.md", links[1].Result.Fix)
+		assert.Equal(t, "The specified file doesn't exist", links[2].Result.Message)
+		assert.Empty(t, links[2].Result.Fix)
+
+		content, err := os.ReadFile(filepath.Join(root, "README.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "# README\n\n[A](docs/guide/intro.md#usage) [A](/docs/guide/intro.md) [B](docs/b.md)\n", string(content))
+	})
+	t.Run("External Links", func(t *testing.T) {
+		//GIVEN
+		svc := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			switch request.URL.Path {
+			case "/moved":
+				http.Redirect(writer, request, "/old", http.StatusMovedPermanently)
+			case "/old":
+				http.Redirect(writer, request, "/page", http.StatusPermanentRedirect)
+			case "/temporary":
+				http.Redirect(writer, request, "/page", http.StatusFound)
+			case "/page":
+				_, _ = writer.Write([]byte(`<h2 id="installation">Installation</h2>`))
+			default:
+				writer.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer svc.Close()
+
+		v := NewValidator(*svc.Client(), &waitMock{})
+		v.pool = newWorkerPool(1)
+		v.fix = true
+		insecure := "http" + strings.TrimPrefix(svc.URL, "https")
+		links := []Link{
+			{TypeOf: ExternalLink, AbsPath: svc.URL + "/moved#installation"},
+			{TypeOf: ExternalLink, AbsPath: svc.URL + "/temporary"},
+			{TypeOf: ExternalLink, AbsPath: svc.URL + "/page#instalation"},
+			{TypeOf: ExternalLink, AbsPath: svc.URL + "/page#usage"},
+			{TypeOf: ExternalLink, AbsPath: insecure + "/page"},
+			{TypeOf: ExternalLink, AbsPath: svc.URL + "/page"},
+		}
+
+		//WHEN
+		result := v.Links(links)
+
+		//THEN
+		require.Len(t, result, 6)
+		assert.Equal(t, svc.URL+"/page#installation", result[0].Result.Fix)
+		assert.Empty(t, result[1].Result.Fix)
+		assert.Equal(t, svc.URL+"/page#installation", result[2].Result.Fix)
+		assert.Equal(t, "The specified anchor doesn't exist on the website. Did you mean #installation?", result[2].Result.Message)
+		assert.Empty(t, result[3].Result.Fix)
+		assert.False(t, result[4].Result.Status)
+		assert.Equal(t, svc.URL+"/page", result[4].Result.Fix)
+		assert.Empty(t, result[5].Result.Fix)
+	})
+
+	t.Run("Headers", func(t *testing.T) {
+		//GIVEN
+		headers := Headers{{Text: "Installation"}, {Text: "Usage"}}
+		links := []Link{
+			{TypeOf: HashInternalLink, RelPath: "#instalation"},
+			{TypeOf: HashInternalLink, RelPath: "#configuration"},
+		}
+
+		//WHEN
+		result := (&Validator{}).Links(links, headers)
+
+		//THEN
+		require.Len(t, result, 2)
+		assert.Equal(t, "The specified header doesn't exist in this file. Did you mean #installation?", result[0].Result.Message)
+		assert.Equal(t, "#installation", result[0].Result.Fix)
+		assert.Equal(t, "The specified header doesn't exist in this file", result[1].Result.Message)
+		assert.Empty(t, result[1].Result.Fix)
+	})
+
+	t.Run("Dry Run", func(t *testing.T) {
+		//GIVEN
+		content := "# Title\n\n[a](a.md)\n\n1\n2\n3\n4\n5\n6\n7\n8\n[b](http://example.com)"
+		file := &File{RelPath: "./docs/README.md", Content: content, Links: Links{
+			{TypeOf: InternalLink, RelPath: "a.md", Line: 3, Column: 1, Text: "[a](a.md)", Result: LinkResult{Fix: "guide/a.md"}},
+			{TypeOf: ExternalLink, AbsPath: "http://example.com", Line: 13, Column: 1, Text: "[b](http://example.com)", Result: LinkResult{Status: true, Fix: "https://example.com"}},
+		}}
+		var output bytes.Buffer
+
+		//WHEN
+		err := Files{file}.WriteFixes(&output)
+
+		//THEN
+		require.NoError(t, err)
+		expected := "--- a/docs/README.md\n+++ b/docs/README.md\n" +
+			"@@ -1,6 +1,6 @@\n # Title\n \n-[a](a.md)\n+[a](guide/a.md)\n \n 1\n 2\n" +
+			"@@ -10,4 +10,4 @@\n 6\n 7\n 8\n-[b](http://example.com)\n\\ No newline at end of file\n" +
+			"+[b](https://example.com)\n\\ No newline at end of file\n"
+		assert.Equal(t, expected, output.String())
+		assert.Equal(t, content, file.Content)
+	})
+}
@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SourceParser extracts links and headers from files of one source format
+type SourceParser interface {
+	Links(basePath, content, dirPath string) Links
+	Headers(content string) Headers
+}
+
+// SourceFormat creates the parser of the source format. The markdown parser carries settings of the file,
+// such as checking links in code blocks, and helpers to build links
+type SourceFormat func(parser *Parser) SourceParser
+
+var (
+	sourceFormatsMu sync.RWMutex
+	sourceFormats   = map[string]SourceFormat{
+		".md":       markdownFormat,
+		".markdown": markdownFormat,
+		".mdx":      mdxFormat,
+		".rst":      rstFormat,
+		".adoc":     asciidocFormat,
+		".asciidoc": asciidocFormat,
+	}
+)
+
+// RegisterSourceFormat adds or replaces the format of files with the given extensions, such as .txt
+func RegisterSourceFormat(format SourceFormat, extensions ...string) {
+	sourceFormatsMu.Lock()
+	defer sourceFormatsMu.Unlock()
+	for _, extension := range extensions {
+		sourceFormats[strings.ToLower(extension)] = format
+	}
+}
+
+// SourceExtensions returns sorted extensions of registered source formats
+func SourceExtensions() []string {
+	sourceFormatsMu.RLock()
+	defer sourceFormatsMu.RUnlock()
+	var extensions []string
+	for extension := range sourceFormats {
+		extensions = append(extensions, extension)
+	}
+	sort.Strings(extensions)
+	return extensions
+}
+
+// NewSourceParser returns the parser registered for the extension of the file
+func NewSourceParser(filePath string, parser *Parser) (SourceParser, bool) {
+	sourceFormatsMu.RLock()
+	format, found := sourceFormats[strings.ToLower(filepath.Ext(filePath))]
+	sourceFormatsMu.RUnlock()
+	if !found {
+		return nil, false
+	}
+	return format(parser), true
+}
+
+func markdownFormat(parser *Parser) SourceParser {
+	return parser
+}
@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceFormats(t *testing.T) {
+	dirPath := "test-markdowns/formats"
+
+	t.Run("reStructuredText", func(t *testing.T) {
+		//GIVEN
+		content, err := readMarkdown("test-markdowns/formats/guide.rst")
+		require.NoError(t, err)
+		parser, found := NewSourceParser("test-markdowns/formats/guide.rst", &Parser{})
+		require.True(t, found)
+
+		//WHEN
+		links := parser.Links("", content, dirPath)
+		headers := parser.Headers(content)
+
+		//THEN
+		expected := Links{
+			Link{AbsPath: "https://www.python.org", TypeOf: ExternalLink, Line: 10, Column: 5, Text: "`Python <https://www.python.org>`_"},
+			Link{AbsPath: "https://github.com/kyma-incubator/milv", TypeOf: ExternalLink, Line: 10, Column: 44, Text: "https://github.com/kyma-incubator/milv"},
+			Link{RelPath: "../images/logo.png", AbsPath: "test-markdowns/images/logo.png", TypeOf: InternalLink, Line: 12, Column: 1, Text: ".. image:: ../images/logo.png"},
+			Link{RelPath: "page.mdx", AbsPath: "test-markdowns/formats/page.mdx", TypeOf: InternalLink, Line: 14, Column: 1, Text: ".. _docs: page.mdx"},
+			Link{RelPath: "#installation-steps", TypeOf: HashInternalLink, Line: 16, Column: 10, Text: "`overview <#installation-steps>`_"},
+			Link{RelPath: "guide.adoc#custom-id", AbsPath: "test-markdowns/formats/guide.adoc#custom-id", TypeOf: InternalLink, Line: 22, Column: 13, Text: "`guide <guide.adoc#custom-id>`_"},
+		}
+		assert.Equal(t, expected, links)
+		assert.Equal(t, Headers{
+			Header{Text: "Guide", ID: "guide", Origin: SectionHeader, Line: 2},
+			Header{ID: "install-target", Origin: AnchorHeader, Line: 5},
+			Header{Text: "Installation Steps", ID: "installation-steps", Origin: SectionHeader, Line: 7},
+		}, headers)
+	})
+
+	t.Run("AsciiDoc", func(t *testing.T) {
+		//GIVEN
+		content, err := readMarkdown("test-markdowns/formats/guide.adoc")
+		require.NoError(t, err)
+		parser, found := NewSourceParser("test-markdowns/formats/guide.adoc", &Parser{})
+		require.True(t, found)
+
+		//WHEN
+		links := parser.Links("", content, dirPath)
+		headers := parser.Headers(content)
+
+		//THEN
+		expected := Links{
+			Link{AbsPath: "https://github.com/kyma-incubator/milv", TypeOf: ExternalLink, Line: 6, Column: 5, Text: "https://github.com/kyma-incubator/milv[MILV]"},
+			Link{RelPath: "../external_links.md", AbsPath: "test-markdowns/external_links.md", TypeOf: InternalLink, Line: 6, Column: 54, Text: "link:../external_links.md[external links]"},
+			Link{RelPath: "#custom-id", TypeOf: HashInternalLink, Line: 8, Column: 9, Text: "<<custom-id>>"},
+			Link{RelPath: "guide.rst#installation-steps", AbsPath: "test-markdowns/formats/guide.rst#installation-steps", TypeOf: InternalLink, Line: 8, Column: 26, Text: "<<guide.rst#installation-steps,installation>>"},
+			Link{RelPath: "../images/logo.png", AbsPath: "test-markdowns/images/logo.png", TypeOf: InternalLink, Line: 10, Column: 1, Text: "image::../images/logo.png[Logo]"},
+			Link{RelPath: "#usage-of-v1-2", TypeOf: HashInternalLink, Line: 18, Column: 1, Text: "xref:usage-of-v1-2[Usage]"},
+		}
+		assert.Equal(t, expected, links)
+		assert.Equal(t, Headers{
+			Header{Text: "Guide", ID: "guide", Origin: SectionHeader, Line: 1},
+			Header{ID: "custom-id", Origin: AnchorHeader, Line: 3},
+			Header{Text: "Usage of v1.2", ID: "usage-of-v1-2", Origin: SectionHeader, Line: 4},
+		}, headers)
+	})
+
+	t.Run("MDX", func(t *testing.T) {
+		//GIVEN
+		content, err := readMarkdown("test-markdowns/formats/page.mdx")
+		require.NoError(t, err)
+		parser, found := NewSourceParser("test-markdowns/formats/page.mdx", &Parser{})
+		require.True(t, found)
+
+		//WHEN
+		links := parser.Links("", content, dirPath)
+
+		//THEN
+		expected := Links{
+			Link{AbsPath: "https://docusaurus.io", TypeOf: ExternalLink, Line: 9, Column: 25, Text: `href="https://docusaurus.io"`},
+			Link{RelPath: "guide.adoc#usage-of-v1-2", AbsPath: "test-markdowns/formats/guide.adoc#usage-of-v1-2", TypeOf: InternalLink, Line: 11, Column: 5, Text: "[the guide](guide.adoc#usage-of-v1-2)"},
+		}
+		assert.Equal(t, expected, links)
+	})
+
+	t.Run("Code Blocks", func(t *testing.T) {
+		//GIVEN
+		rst, err := readMarkdown("test-markdowns/formats/guide.rst")
+		require.NoError(t, err)
+		adoc, err := readMarkdown("test-markdowns/formats/guide.adoc")
+		require.NoError(t, err)
+
+		//WHEN
+		rstLinks := rstFormat(&Parser{CodeBlocks: true}).Links("", rst, dirPath)
+		adocLinks := asciidocFormat(&Parser{CodeBlocks: true}).Links("", adoc, dirPath)
+
+		//THEN
+		assert.Contains(t, rstLinks, Link{AbsPath: "https://example.com/in-code-block", TypeOf: ExternalLink, Line: 20, Column: 5, Text: "https://example.com/in-code-block"})
+		assert.Contains(t, adocLinks, Link{AbsPath: "https://example.com/in-listing", TypeOf: ExternalLink, Line: 15, Column: 1, Text: "https://example.com/in-listing"})
+		assert.Len(t, adocLinks, 7)
+	})
+
+	t.Run("Register Source Format", func(t *testing.T) {
+		//GIVEN
+		_, found := NewSourceParser("notes.txt", &Parser{})
+		require.False(t, found)
+
+		//WHEN
+		RegisterSourceFormat(markdownFormat, ".TXT")
+		defer func() {
+			sourceFormatsMu.Lock()
+			delete(sourceFormats, ".txt")
+			sourceFormatsMu.Unlock()
+		}()
+
+		//THEN
+		_, found = NewSourceParser("notes.txt", &Parser{})
+		assert.True(t, found)
+		assert.Contains(t, SourceExtensions(), ".txt")
+	})
+}
@@ -0,0 +1,198 @@
+package pkg
+
+import (
+	"bufio"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	gitignoreFile  = ".gitignore"
+	milvignoreFile = ".milvignore"
+
+	linkRulePrefix = "link:"
+)
+
+// ignoreFiles reads .gitignore and .milvignore files of the base path and its subdirectories.
+// Files are read when a path in their directory is checked for the first time.
+type ignoreFiles struct {
+	root      string
+	gitignore bool
+	dirs      map[string]*ignoreFile
+}
+
+func newIgnoreFiles(root string, gitignore bool) *ignoreFiles {
+	return &ignoreFiles{root: root, gitignore: gitignore, dirs: map[string]*ignoreFile{}}
+}
+
+// ignored checks the path relative to the base path against ignore files of all its parent directories.
+// Rules of the deeper directory take precedence.
+func (i *ignoreFiles) ignored(relPath string, dir bool) bool {
+	ignored := false
+	for _, parent := range parentDirs(relPath) {
+		if result, matched := i.load(parent).match(relativeTo(parent, relPath), dir); matched {
+			ignored = result
+		}
+	}
+	return ignored
+}
+
+// linksToIgnore returns patterns of links which .milvignore files exclude in the file
+func (i *ignoreFiles) linksToIgnore(relPath string) []string {
+	var patterns []string
+	for _, parent := range parentDirs(relPath) {
+		patterns = append(patterns, i.load(parent).linksToIgnore(relativeTo(parent, relPath))...)
+	}
+	return patterns
+}
+
+func (i *ignoreFiles) load(dir string) *ignoreFile {
+	if file, found := i.dirs[dir]; found {
+		return file
+	}
+
+	file := &ignoreFile{}
+	if i.gitignore {
+		file.read(filepath.Join(i.root, dir, gitignoreFile), false)
+	}
+	file.read(filepath.Join(i.root, dir, milvignoreFile), true)
+	i.dirs[dir] = file
+	return file
+}
+
+// parentDirs returns directories which contain the path, starting with the base path
+func parentDirs(relPath string) []string {
+	var dirs []string
+	for dir := path.Dir(relPath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		dirs = append([]string{dir}, dirs...)
+	}
+	return append([]string{""}, dirs...)
+}
+
+func relativeTo(dir, relPath string) string {
+	if dir == "" {
+		return relPath
+	}
+	return strings.TrimPrefix(relPath, dir+"/")
+}
+
+// ignoreFile holds rules of the .gitignore or .milvignore file. It supports comments, negated rules (!),
+// rules for directories only (build/), rules anchored to the directory of the file (/build) and glob patterns.
+// The .milvignore file can also exclude links in files with the link: rule, such as
+// link: docs/api/** re:^https://internal\.example\.com
+type ignoreFile struct {
+	rules []ignoreRule
+	links []linkRule
+}
+
+type ignoreRule struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+type linkRule struct {
+	path    *regexp.Regexp
+	pattern string
+}
+
+// read adds rules of the file, the missing file has no rules
+func (f *ignoreFile) read(filePath string, links bool) {
+	file, err := os.Open(filePath)
+	if os.IsNotExist(err) {
+		return
+	}
+	if err != nil {
+		log.Printf("Error while reading %s, its rules are skipped: %+v", filePath, err)
+		return
+	}
+	defer CloseBody(file)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if links && strings.HasPrefix(line, linkRulePrefix) {
+			rule, err := newLinkRule(strings.TrimPrefix(line, linkRulePrefix))
+			if err != nil {
+				log.Printf("Invalid rule %q in %s is skipped: %v", line, filePath, err)
+				continue
+			}
+			f.links = append(f.links, rule)
+		} else if rule, ok := newIgnoreRule(line); ok {
+			f.rules = append(f.rules, rule)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Printf("Error while reading %s: %+v", filePath, err)
+	}
+}
+
+func newIgnoreRule(line string) (ignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	}
+	line = strings.TrimPrefix(line, `\`)
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	rule.re = pathPattern(line)
+	return rule, true
+}
+
+func newLinkRule(line string) (linkRule, error) {
+	fields := strings.Fields(line)
+	if len(fields) != 2 {
+		return linkRule{}, errors.New("The rule must have the path and the link pattern")
+	}
+	if _, err := newIgnorePattern(fields[1]); err != nil {
+		return linkRule{}, err
+	}
+	return linkRule{path: pathPattern(fields[0]), pattern: fields[1]}, nil
+}
+
+// pathPattern converts the pattern of the ignore file to the regular expression,
+// the pattern without a slash in the middle matches at any level
+func pathPattern(pattern string) *regexp.Regexp {
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	return regexp.MustCompile(globToRegex(strings.TrimPrefix(pattern, "/")))
+}
+
+// match checks the path relative to the directory of the ignore file.
+// The last matching rule decides, so negated rules can include files again.
+func (f *ignoreFile) match(relPath string, dir bool) (ignored, matched bool) {
+	for _, rule := range f.rules {
+		if rule.dirOnly && !dir {
+			continue
+		}
+		if rule.re.MatchString(relPath) {
+			ignored, matched = !rule.negate, true
+		}
+	}
+	return ignored, matched
+}
+
+func (f *ignoreFile) linksToIgnore(relPath string) []string {
+	var patterns []string
+	for _, rule := range f.links {
+		if rule.path.MatchString(relPath) {
+			patterns = append(patterns, rule.pattern)
+		}
+	}
+	return patterns
+}
@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoreFile(t *testing.T) {
+	//GIVEN
+	ignore := &ignoreFile{}
+	for _, line := range []string{"# comment", "", "/node_modules", "build/", "*.log", "!important.log", "docs/*.tmp"} {
+		if rule, ok := newIgnoreRule(line); ok {
+			ignore.rules = append(ignore.rules, rule)
+		}
+	}
+
+	tcs := []struct {
+		Path    string
+		Dir     bool
+		Ignored bool
+	}{
+		{Path: "node_modules", Dir: true, Ignored: true},
+		{Path: "web/node_modules", Dir: true, Ignored: false},
+		{Path: "web/build", Dir: true, Ignored: true},
+		{Path: "build", Dir: false, Ignored: false},
+		{Path: "logs/debug.log", Ignored: true},
+		{Path: "logs/important.log", Ignored: false},
+		{Path: "docs/a.tmp", Ignored: true},
+		{Path: "docs/sub/a.tmp", Ignored: false},
+		{Path: "README.md", Ignored: false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Path, func(t *testing.T) {
+			//WHEN
+			result, _ := ignore.match(tc.Path, tc.Dir)
+
+			//THEN
+			assert.Equal(t, tc.Ignored, result)
+		})
+	}
+}
+
+func TestIgnoreFiles(t *testing.T) {
+	root := t.TempDir()
+	for filePath, content := range map[string]string{
+		".gitignore":       "*.tmp\n",
+		".milvignore":      "link: docs/** re:^https://internal\\.example\\.com/\nlink: *.md #todo\nlink: invalid\n",
+		"docs/.gitignore":  "!keep.tmp\n",
+		"docs/.milvignore": "link: api/*.md glob:https://*.example.com/**\n",
+	} {
+		fullPath := filepath.Join(root, filePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+
+	t.Run("Nested Ignore Files", func(t *testing.T) {
+		//GIVEN
+		ignores := newIgnoreFiles(root, true)
+
+		//THEN
+		assert.True(t, ignores.ignored("notes.tmp", false))
+		assert.True(t, ignores.ignored("docs/notes.tmp", false))
+		assert.False(t, ignores.ignored("docs/keep.tmp", false))
+		assert.False(t, newIgnoreFiles(root, false).ignored("notes.tmp", false))
+	})
+
+	t.Run("Links To Ignore", func(t *testing.T) {
+		//GIVEN
+		ignores := newIgnoreFiles(root, true)
+
+		//THEN
+		assert.Equal(t, []string{"#todo"}, ignores.linksToIgnore("README.md"))
+		assert.Equal(t, []string{"re:^https://internal\\.example\\.com/", "#todo"}, ignores.linksToIgnore("docs/guide.md"))
+		assert.Equal(t, []string{"re:^https://internal\\.example\\.com/", "#todo", "glob:https://*.example.com/**"}, ignores.linksToIgnore("docs/api/v1.md"))
+	})
+
+	t.Run("File Config", func(t *testing.T) {
+		//GIVEN
+		config := &Config{BasePath: root, ExternalLinksToIgnore: []string{"localhost"}}
+
+		//WHEN
+		fileConfig := NewFileConfig(filepath.Join(root, "docs/guide.md"), config)
+
+		//THEN
+		assert.ElementsMatch(t, []string{"localhost", "re:^https://internal\\.example\\.com/", "#todo"}, fileConfig.ExternalLinksToIgnore)
+		assert.ElementsMatch(t, []string{"re:^https://internal\\.example\\.com/", "#todo"}, fileConfig.InternalLinksToIgnore)
+	})
+}
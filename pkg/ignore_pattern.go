@@ -0,0 +1,91 @@
+package pkg
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	regexPatternPrefix = "re:"
+	globPatternPrefix  = "glob:"
+)
+
+// ignorePattern matches links from the lists of links to ignore.
+// Entries with the re: prefix are regular expressions, entries with the glob: prefix are glob patterns
+// and other entries keep the previous behavior: external links contain them, internal links are equal to them.
+type ignorePattern struct {
+	value string
+	re    *regexp.Regexp
+}
+
+func newIgnorePattern(pattern string) (ignorePattern, error) {
+	switch {
+	case strings.HasPrefix(pattern, regexPatternPrefix):
+		re, err := regexp.Compile(strings.TrimPrefix(pattern, regexPatternPrefix))
+		if err != nil {
+			return ignorePattern{}, errors.Wrapf(err, "Invalid pattern %q", pattern)
+		}
+		return ignorePattern{value: pattern, re: re}, nil
+	case strings.HasPrefix(pattern, globPatternPrefix):
+		re := regexp.MustCompile(globToRegex(strings.TrimPrefix(pattern, globPatternPrefix)))
+		return ignorePattern{value: pattern, re: re}, nil
+	}
+	return ignorePattern{value: pattern}, nil
+}
+
+func (p ignorePattern) matchExternal(link string) bool {
+	if p.re != nil {
+		return p.re.MatchString(link)
+	}
+	return strings.Contains(link, p.value)
+}
+
+func (p ignorePattern) matchInternal(link string) bool {
+	if p.re != nil {
+		return p.re.MatchString(link)
+	}
+	return link == p.value
+}
+
+// globToRegex converts the glob pattern to the valid regular expression matching the whole link or file path.
+// The * matches any characters except /, the ** matches any characters, the **/ matches any directories, also none,
+// and the ? matches a single character except /.
+func globToRegex(glob string) string {
+	var re strings.Builder
+	re.WriteString("^")
+	runes := []rune(glob)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '*':
+			if i+2 < len(runes) && runes[i+1] == '*' && runes[i+2] == '/' {
+				re.WriteString("(.*/)?")
+				i += 2
+			} else if i+1 < len(runes) && runes[i+1] == '*' {
+				re.WriteString(".*")
+				i++
+			} else {
+				re.WriteString("[^/]*")
+			}
+		case '?':
+			re.WriteString("[^/]")
+		default:
+			re.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	re.WriteString("$")
+	return re.String()
+}
+
+// validateIgnorePatterns returns the error of the first invalid pattern in the lists
+func validateIgnorePatterns(lists ...[]string) error {
+	for _, list := range lists {
+		for _, pattern := range list {
+			if _, err := newIgnorePattern(pattern); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
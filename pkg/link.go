@@ -6,6 +6,29 @@ const (
 	ExternalLink     LinkType = "ExternalLink"
 	InternalLink     LinkType = "InternalLink"
 	HashInternalLink LinkType = "HashInternalLink"
+
+	// ReferenceLink is the reference or the link definition which is broken in the file itself
+	ReferenceLink LinkType = "ReferenceLink"
+)
+
+// FailureKind describes why the link is broken
+type FailureKind string
+
+const (
+	InvalidURL         FailureKind = "InvalidURL"
+	RequestError       FailureKind = "RequestError"
+	RequestTimeout     FailureKind = "RequestTimeout"
+	TooManyRequests    FailureKind = "TooManyRequests"
+	HTTPStatus         FailureKind = "HTTPStatus"
+	MissingAnchor      FailureKind = "MissingAnchor"
+	MissingFile        FailureKind = "MissingFile"
+	MissingHeader      FailureKind = "MissingHeader"
+	UndefinedReference FailureKind = "UndefinedReference"
+	UnusedDefinition   FailureKind = "UnusedDefinition"
+	PermanentRedirect  FailureKind = "PermanentRedirect"
+	TemporaryRedirect  FailureKind = "TemporaryRedirect"
+	RedirectLoop       FailureKind = "RedirectLoop"
+	TooManyRedirects   FailureKind = "TooManyRedirects"
 )
 
 type Link struct {
@@ -13,10 +36,23 @@ type Link struct {
 	AbsPath string
 	Config  *LinkConfig `yaml:"config"`
 	TypeOf  LinkType
+	Line    int
+	Column  int
+	Text    string
 	Result  LinkResult
 }
 
 type LinkResult struct {
 	Status  bool
 	Message string
+	Kind    FailureKind
+	// Skipped tells the link wasn't checked because of the milv-disable comment
+	Skipped bool
+	// Fix is the link which replaces the broken link, such as the current path of the moved file
+	Fix string
+	// Severity is the severity of the problem described by the message and the kind, if the link is valid
+	// anyway. Links with warnings and information should be fixed, but they don't break the check.
+	Severity Severity
+	// Redirects are redirects of the external link, in the order they were followed
+	Redirects []Redirect
 }
@@ -1,9 +1,5 @@
 package pkg
 
-import (
-	"strings"
-)
-
 type Links []Link
 
 func NewLinks(filePath string, config *Config) Links {
@@ -26,22 +22,24 @@ func (l Links) AppendConfig(file *File) Links {
 	return links
 }
 
+// RemoveIgnoredLinks removes links which match the patterns of links to ignore. See ignorePattern for the syntax
 func (l Links) RemoveIgnoredLinks(externals, internals []string) Links {
+	externalPatterns, internalPatterns := ignorePatterns(externals), ignorePatterns(internals)
 	links := l[:0]
 	exist := false
 
 	for _, link := range l {
 		exist = false
 		if link.TypeOf == ExternalLink {
-			for _, w_link := range externals {
-				if strings.Contains(link.AbsPath, w_link) {
+			for _, pattern := range externalPatterns {
+				if pattern.matchExternal(link.AbsPath) {
 					exist = true
 					break
 				}
 			}
 		} else {
-			for _, w_link := range internals {
-				if link.RelPath == w_link {
+			for _, pattern := range internalPatterns {
+				if pattern.matchInternal(link.RelPath) {
 					exist = true
 					break
 				}
@@ -54,6 +52,20 @@ func (l Links) RemoveIgnoredLinks(externals, internals []string) Links {
 	return links
 }
 
+// ignorePatterns skips invalid patterns, they are reported when the configuration is loaded
+func ignorePatterns(patterns []string) []ignorePattern {
+	var result []ignorePattern
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		if compiled, err := newIgnorePattern(pattern); err == nil {
+			result = append(result, compiled)
+		}
+	}
+	return result
+}
+
 func (l Links) CheckStatus() bool {
 	for _, link := range l {
 		if !link.Result.Status {
@@ -74,6 +74,34 @@ func TestLinks(t *testing.T) {
 		assert.Equal(t, expected, result)
 	})
 
+	t.Run("Remove Ignored Links By Patterns", func(t *testing.T) {
+		//GIVEN
+		externalLinksToIgnore := []string{"re:^https?://localhost(:\\d+)?/", "glob:https://*.example.com/**"}
+		internalLinksToIgnore := []string{"glob:../drafts/*.md", "re:^#todo-"}
+		links := Links{
+			Link{AbsPath: "http://localhost:8080/api", TypeOf: ExternalLink},
+			Link{AbsPath: "https://github.com/kyma-incubator/localhost", TypeOf: ExternalLink},
+			Link{AbsPath: "https://docs.example.com/guide/setup", TypeOf: ExternalLink},
+			Link{AbsPath: "https://example.com/guide", TypeOf: ExternalLink},
+			Link{RelPath: "../drafts/new.md", TypeOf: InternalLink},
+			Link{RelPath: "../drafts/old/new.md", TypeOf: InternalLink},
+			Link{RelPath: "#todo-later", TypeOf: HashInternalLink},
+			Link{RelPath: "#setup", TypeOf: HashInternalLink},
+		}
+
+		//WHEN
+		result := links.RemoveIgnoredLinks(externalLinksToIgnore, internalLinksToIgnore)
+
+		//THEN
+		expected := Links{
+			Link{AbsPath: "https://github.com/kyma-incubator/localhost", TypeOf: ExternalLink},
+			Link{AbsPath: "https://example.com/guide", TypeOf: ExternalLink},
+			Link{RelPath: "../drafts/old/new.md", TypeOf: InternalLink},
+			Link{RelPath: "#setup", TypeOf: HashInternalLink},
+		}
+		assert.Equal(t, expected, result)
+	})
+
 	t.Run("Filter", func(t *testing.T) {
 		// given
 		links := Links{
@@ -4,35 +4,100 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
 	"path"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"golang.org/x/net/html"
 )
 
-type Parser struct{}
+const (
+	CommonMarkParser = "commonmark"
+	RegexParser      = "regex"
+)
+
+type Parser struct {
+	// Regex scans the markdown line by line with regular expressions, like the previous versions did,
+	// instead of parsing it as CommonMark with GitHub Flavored Markdown extensions
+	Regex bool
+	// CodeBlocks allows links in code blocks and code spans
+	CodeBlocks bool
+}
+
+func NewParser(config FileConfig) *Parser {
+	return &Parser{
+		Regex:      config.Parser == RegexParser,
+		CodeBlocks: config.AllowCodeBlocks == nil || *config.AllowCodeBlocks,
+	}
+}
 
-type match func([][]string) string
+type match func([]string) string
+
+// token is the matched value with the line and the column it was found at,
+// and the whole matched text as it is written in the file
+type token struct {
+	Value  string
+	Line   int
+	Column int
+	Text   string
+	Kind   FailureKind
+}
 
 const (
-	// this regex catch 2 things, markdown URL or normal URL
+	// this regex catch 2 things, markdown URL (or image) or normal URL
 	// markdown url consists of 2 groups: [text](url)
-	linkPattern   = `\[([^\]]*)\]\(([^)]*)\)|\bhttps?://\S*\b`
+	linkPattern   = `!?\[([^\[\]]*)\]\(([^)]*)\)|\bhttps?://\S*\b`
 	headerPattern = `^#{1,6}? (.*)`
 	httpsPattern  = `^https?://`
 	hashPattern   = `^#(.*)`
+	// the explicit ID of the heading, supported by Hugo and Docusaurus
+	headingIDPattern = `\{#([^\s{}]+)\}\s*$`
+	// links with these schemes, e.g. e-mail addresses, can't be validated
+	ignoredSchemePattern = `^(?i)(mailto|tel|javascript|data):`
 
 	urlCatchGroup = 2
 )
 
 func (p *Parser) Links(basePath, markdown, dirPath string) Links {
+	if !p.Regex {
+		return p.extractLinks(basePath, parseDocument(markdown).links(p.CodeBlocks, p.getLink), dirPath)
+	}
+
+	if !p.CodeBlocks {
+		markdown = removeCodeBlocks(markdown)
+	}
 	return p.extractLinks(basePath, p.parse(markdown, linkPattern, p.getLink), dirPath)
 }
 
-func (p *Parser) Headers(markdown string) []string {
-	return p.parse(markdown, headerPattern, p.getHeader)
+func (p *Parser) Headers(markdown string) Headers {
+	if !p.Regex {
+		return parseDocument(markdown).headers()
+	}
+
+	var headers Headers
+	for _, header := range p.parse(markdown, headerPattern, p.getHeader) {
+		h := newHeader(header.Value)
+		h.Origin = ATXHeader
+		h.Line = header.Line
+		headers = append(headers, h)
+	}
+	return headers
+}
+
+// Directives returns directives in HTML comments, which are HTML blocks or raw HTML of the document,
+// so comments in code blocks and code spans are skipped
+func (p *Parser) Directives(markdown string) []Directive {
+	return parseDocument(markdown).directives()
+}
+
+// newHeader returns the header with the explicit ID, if the text ends with {#custom-id}
+func newHeader(text string) Header {
+	header := Header{Text: text}
+	if matches := regexp.MustCompile(headingIDPattern).FindStringSubmatch(text); matches != nil {
+		header.ID = matches[1]
+	}
+	return header
 }
 
 func (p *Parser) Anchors(body io.ReadCloser) (ids []string) {
@@ -44,7 +109,7 @@ func (p *Parser) Anchors(body io.ReadCloser) (ids []string) {
 		case tt == html.ErrorToken:
 			return
 		case tt == html.StartTagToken:
-			id := p.getId(z.Token())
+			id := getId(z.Token())
 			if id != "" {
 				// github always add "user-content-" prefix to anchor in .md files
 				id = p.removePrefixFomAnchor(id)
@@ -52,55 +117,77 @@ func (p *Parser) Anchors(body io.ReadCloser) (ids []string) {
 			}
 		}
 	}
-	return
 }
 
-func (*Parser) parse(markdown, pattern string, match match) []string {
-	var result []string
+func (*Parser) parse(markdown, pattern string, match match) []token {
+	var result []token
 	re := regexp.MustCompile(pattern)
 
 	scanner := bufio.NewScanner(strings.NewReader(markdown))
 
+	line := 0
 	for scanner.Scan() {
-		matches := re.FindAllStringSubmatch(scanner.Text(), -1)
-		if matches != nil {
-			result = append(result, match(matches))
+		line++
+		result = append(result, matchLine(re, scanner.Text(), line, 1, match)...)
+	}
+	return result
+}
+
+// matchLine returns tokens for all matches of the regex in the text, which starts at the given line and column
+func matchLine(re *regexp.Regexp, text string, line, column int, match match) []token {
+	var result []token
+	for _, indexes := range re.FindAllStringSubmatchIndex(text, -1) {
+		submatches := make([]string, len(indexes)/2)
+		for i := range submatches {
+			if indexes[2*i] >= 0 {
+				submatches[i] = text[indexes[2*i]:indexes[2*i+1]]
+			}
 		}
+
+		result = append(result, token{
+			Value:  match(submatches),
+			Line:   line,
+			Column: column + utf8.RuneCountInString(text[:indexes[0]]),
+			Text:   submatches[0],
+		})
 	}
 	return result
 }
 
-func (*Parser) getLink(matches [][]string) string {
-	substring := strings.Split(matches[0][urlCatchGroup], " ")[0]
+func (*Parser) getLink(matches []string) string {
+	substring := strings.Split(matches[urlCatchGroup], " ")[0]
 	if substring == "" {
-		return matches[0][0]
+		return matches[0]
 	}
 	return substring
 }
 
-func (p *Parser) getHeader(matches [][]string) string {
-	header := matches[0][1]
-
+func (p *Parser) getHeader(matches []string) string {
 	re := regexp.MustCompile(`]\(([^)]*)\)`)
-	header = re.ReplaceAllString(header, "")
-
-	reg, err := regexp.Compile("[^a-zA-Z0-9 -]+")
-	if err != nil {
-		log.Fatal(err)
-	}
-	return reg.ReplaceAllString(header, "")
+	return re.ReplaceAllString(matches[1], "")
 }
 
-func (p *Parser) extractLinks(basePath string, links []string, dirPath string) Links {
+func (p *Parser) extractLinks(basePath string, links []token, dirPath string) Links {
 	var extractedLinks Links
-	for _, link := range links {
-		if match, _ := regexp.MatchString(httpsPattern, link); match {
-			extractedLinks = append(extractedLinks, p.externalLink(link))
-		} else if match, _ := regexp.MatchString(hashPattern, link); match {
-			extractedLinks = append(extractedLinks, p.hashInternalLink(link))
+	for _, token := range links {
+		if match, _ := regexp.MatchString(ignoredSchemePattern, token.Value); match {
+			continue
+		}
+
+		var link Link
+		if token.Kind != "" {
+			link = p.referenceLink(token.Value, token.Kind)
+		} else if match, _ := regexp.MatchString(httpsPattern, token.Value); match {
+			link = p.externalLink(token.Value)
+		} else if match, _ := regexp.MatchString(hashPattern, token.Value); match {
+			link = p.hashInternalLink(token.Value)
 		} else {
-			extractedLinks = append(extractedLinks, p.internalLink(basePath, link, dirPath))
+			link = p.internalLink(basePath, token.Value, dirPath)
 		}
+		link.Line = token.Line
+		link.Column = token.Column
+		link.Text = token.Text
+		extractedLinks = append(extractedLinks, link)
 	}
 	return extractedLinks
 }
@@ -121,6 +208,24 @@ func (p *Parser) hashInternalLink(link string) Link {
 	}
 }
 
+func (p *Parser) referenceLink(label string, kind FailureKind) Link {
+	message := "The reference isn't defined in this file"
+	if kind == UnusedDefinition {
+		message = "The link definition isn't used in this file"
+	}
+
+	return Link{
+		RelPath: label,
+		AbsPath: "",
+		TypeOf:  ReferenceLink,
+		Result: LinkResult{
+			Status:  false,
+			Message: message,
+			Kind:    kind,
+		},
+	}
+}
+
 func (p *Parser) internalLink(basePath, link, dirPath string) Link {
 	var absPath string
 
@@ -137,7 +242,7 @@ func (p *Parser) internalLink(basePath, link, dirPath string) Link {
 	}
 }
 
-func (*Parser) getId(t html.Token) string {
+func getId(t html.Token) string {
 	for _, attr := range t.Attr {
 		if attr.Key == "id" || (t.Data == "a" && attr.Key == "name") {
 			return attr.Val
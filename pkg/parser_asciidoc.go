@@ -0,0 +1,151 @@
+package pkg
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// asciidocLinkPattern catches, in this order: cross references <<id,text>>, macros with targets,
+	// such as link:path[text], xref:file.adoc#id[text] or image::path[], and URLs, also with [text]
+	asciidocLinkPattern = `<<([^,>\s]+)(?:,[^>]*)?>>` +
+		`|\b(link|xref|image|include|video|audio):{1,2}([^\s\[]+)\[[^\]]*\]` +
+		`|\b(https?://[^\s\[\]<>]*[^\s\[\]<>.,;:!?'")])(?:\[[^\]]*\])?`
+	// asciidocTitlePattern is the section title, such as == Installation
+	asciidocTitlePattern = `^(={1,6})\s+(.+?)\s*$`
+	// asciidocAnchorPattern is the anchor, such as [[install]], [[install,Installation]], [#install] or anchor:install[]
+	asciidocAnchorPattern = `\[\[([\w:.-]+)(?:,[^\]]*)?\]\]|^\[#([\w:.-]+)[^\]]*\]\s*$|\banchor:([\w:.-]+)\[`
+	// asciidocDelimiterPattern is the delimiter of listing, literal and comment blocks
+	asciidocDelimiterPattern = `^(-{4,}|\.{4,}|/{4,})\s*$`
+)
+
+// asciidocParser parses AsciiDoc files
+type asciidocParser struct {
+	*Parser
+}
+
+func asciidocFormat(parser *Parser) SourceParser {
+	return asciidocParser{parser}
+}
+
+func (p asciidocParser) Links(basePath, content, dirPath string) Links {
+	re := regexp.MustCompile(asciidocLinkPattern)
+
+	var tokens []token
+	for i, line := range p.lines(content) {
+		tokens = append(tokens, matchLine(re, line, i+1, 1, asciidocLink)...)
+	}
+	return p.extractLinks(basePath, tokens, dirPath)
+}
+
+// asciidocLink returns the target of the match. Cross references without the file, such as <<install>>
+// or xref:install[], refer to the anchor in the same file
+func asciidocLink(matches []string) string {
+	switch {
+	case matches[1] != "":
+		return asciidocReference(matches[1])
+	case matches[2] == "xref":
+		return asciidocReference(matches[3])
+	case matches[3] != "":
+		return matches[3]
+	}
+	return matches[4]
+}
+
+func asciidocReference(target string) string {
+	if strings.Contains(target, "#") || strings.Contains(target, ".") {
+		return target
+	}
+	return "#" + target
+}
+
+// Headers returns section titles and anchors. IDs of section titles are generated the same way as GitHub does
+func (p asciidocParser) Headers(content string) Headers {
+	title := regexp.MustCompile(asciidocTitlePattern)
+	anchor := regexp.MustCompile(asciidocAnchorPattern)
+
+	var headers Headers
+	for i, line := range p.lines(content) {
+		if matches := title.FindStringSubmatch(line); matches != nil {
+			headers = append(headers, Header{Text: matches[2], ID: asciidocID(matches[2]), Origin: SectionHeader, Line: i + 1})
+			continue
+		}
+		for _, matches := range anchor.FindAllStringSubmatch(line, -1) {
+			id := matches[1] + matches[2] + matches[3]
+			headers = append(headers, Header{ID: id, Origin: AnchorHeader, Line: i + 1})
+		}
+	}
+	return headers
+}
+
+// Directives returns directives in line comments, such as // milv-disable. Comments in blocks are skipped
+func (p asciidocParser) Directives(content string) []Directive {
+	delimiter := regexp.MustCompile(asciidocDelimiterPattern)
+
+	var directives []Directive
+	// block is the delimiter of the open block
+	block := ""
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case block == "" && delimiter.MatchString(line):
+			block = trimmed
+		case block != "":
+			if trimmed == block {
+				block = ""
+			}
+		case strings.HasPrefix(line, "//"):
+			if directive, found := commentDirective(line[len("//"):], i+1); found {
+				directives = append(directives, directive)
+			}
+		}
+	}
+	return directives
+}
+
+// lines returns lines of the file. Lines of comments are always empty,
+// and lines of listing and literal blocks are empty unless links in code blocks are checked
+func (p asciidocParser) lines(content string) []string {
+	delimiter := regexp.MustCompile(asciidocDelimiterPattern)
+
+	lines := strings.Split(content, "\n")
+	// block is the delimiter of the open block
+	block := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case block == "" && delimiter.MatchString(line):
+			block = trimmed
+			lines[i] = ""
+		case block != "" && trimmed == block:
+			block = ""
+			lines[i] = ""
+		case block != "":
+			if !p.CodeBlocks || strings.HasPrefix(block, "/") {
+				lines[i] = ""
+			}
+		case strings.HasPrefix(line, "//"):
+			lines[i] = ""
+		}
+	}
+	return lines
+}
+
+// asciidocID reproduces IDs of section titles generated by Asciidoctor with the settings of GitHub,
+// an empty prefix and the hyphen separator: letters, digits and underscores are kept,
+// and spaces, periods and hyphens become one hyphen
+func asciidocID(title string) string {
+	var id strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r == ' ' || r == '.' || r == '-':
+			if !strings.HasSuffix(id.String(), "-") {
+				id.WriteRune('-')
+			}
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			id.WriteRune(r)
+		}
+	}
+	return strings.Trim(id.String(), "-")
+}
@@ -0,0 +1,621 @@
+package pkg
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/net/html"
+)
+
+var (
+	markdownParser = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithParserOptions(parser.WithParagraphTransformers(
+			// runs before the transformer which removes link definitions from paragraphs
+			util.Prioritized(definitionsRecorder{}, 50),
+		)),
+	)
+	definitionsKey = parser.NewContextKey()
+	// htmlLinkAttributes are attributes of HTML elements which contain URLs
+	htmlLinkAttributes = map[string]bool{"href": true, "src": true, "srcset": true, "poster": true}
+	htmlHeadingPattern = regexp.MustCompile(`^h[1-6]$`)
+	// definitionPattern matches the label of the link definition, such as [label]: https://...
+	definitionPattern = regexp.MustCompile(`^ {0,3}\[((?:[^\[\]\\]|\\.)+)\]:`)
+	// referencePattern matches full and collapsed reference links, such as [text][label] or [text][]
+	referencePattern = regexp.MustCompile(`!?\[((?:[^\[\]\\]|\\.)*)\]\[((?:[^\[\]\\]|\\.)*)\]`)
+)
+
+// definitionsRecorder keeps lines of paragraphs which may contain link definitions,
+// as the parser removes them from the document
+type definitionsRecorder struct{}
+
+func (definitionsRecorder) Transform(node *ast.Paragraph, reader text.Reader, pc parser.Context) {
+	segments, _ := pc.Get(definitionsKey).([]text.Segment)
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		segments = append(segments, lines.At(i))
+	}
+	pc.Set(definitionsKey, segments)
+}
+
+// definition is the link definition with the offset it was found at
+type definition struct {
+	label  string
+	offset int
+	text   string
+}
+
+// document is the markdown file parsed by the CommonMark parser with GitHub Flavored Markdown extensions
+type document struct {
+	source     []byte
+	root       ast.Node
+	lineStarts []int
+	// cursor is the offset in the source from which the next link is searched
+	cursor int
+	// ends are offsets after links the walk is in
+	ends []int
+	// definitions are link definitions by the normalized label
+	definitions map[string]definition
+	// used are normalized labels of link definitions which links refer to
+	used map[string]bool
+}
+
+func parseDocument(content string) *document {
+	source := []byte(content)
+	lineStarts := []int{0}
+	for i, c := range source {
+		if c == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+
+	context := parser.NewContext()
+	root := markdownParser.Parser().Parse(text.NewReader(source), parser.WithContext(context))
+
+	definitions := map[string]definition{}
+	segments, _ := context.Get(definitionsKey).([]text.Segment)
+	for _, segment := range segments {
+		line := segment.Value(source)
+		indexes := definitionPattern.FindSubmatchIndex(line)
+		if indexes == nil {
+			continue
+		}
+		label := string(line[indexes[2]:indexes[3]])
+		key := util.ToLinkReference([]byte(label))
+		if _, found := definitions[key]; found {
+			continue
+		}
+		if _, found := context.Reference(key); found {
+			definitions[key] = definition{
+				label:  label,
+				offset: segment.Start + indexes[2] - 1,
+				text:   strings.TrimSpace(string(line[indexes[2]-1:])),
+			}
+		}
+	}
+
+	return &document{
+		source:      source,
+		root:        root,
+		lineStarts:  lineStarts,
+		definitions: definitions,
+		used:        map[string]bool{},
+	}
+}
+
+// links returns links, images and autolinks in the order they appear in the document, with URLs of HTML elements.
+// Code blocks and code spans aren't parsed as markdown, so they're scanned with the regex.
+func (d *document) links(codeBlocks bool, match match) []token {
+	var result []token
+	re := regexp.MustCompile(linkPattern)
+
+	ast.Walk(d.root, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			if node.Kind() == ast.KindLink || node.Kind() == ast.KindImage {
+				d.cursor = d.ends[len(d.ends)-1]
+				d.ends = d.ends[:len(d.ends)-1]
+			}
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.Link:
+			result = append(result, d.linkToken(n, string(n.Destination), false))
+		case *ast.Image:
+			result = append(result, d.linkToken(n, string(n.Destination), true))
+		case *ast.AutoLink:
+			if n.AutoLinkType == ast.AutoLinkURL {
+				result = append(result, d.autoLinkToken(n))
+			}
+		case *ast.CodeSpan:
+			if codeBlocks {
+				result = append(result, d.scanSegments(re, childSegments(n), match)...)
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			if codeBlocks {
+				result = append(result, d.scanSegments(re, n.Lines(), match)...)
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.HTMLBlock:
+			segments := n.Lines()
+			if n.HasClosure() {
+				segments.Append(n.ClosureLine)
+			}
+			result = append(result, d.htmlLinks(segments)...)
+		case *ast.RawHTML:
+			result = append(result, d.htmlLinks(n.Segments)...)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	result = append(result, d.undefinedReferences()...)
+	result = append(result, d.unusedDefinitions()...)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Line != result[j].Line {
+			return result[i].Line < result[j].Line
+		}
+		return result[i].Column < result[j].Column
+	})
+	return result
+}
+
+// undefinedReferences returns reference links which labels aren't defined. The parser leaves them as text,
+// so adjoining text nodes are joined and searched for references.
+func (d *document) undefinedReferences() []token {
+	var result []token
+	start, end := -1, -1
+	flush := func() {
+		if start < 0 {
+			return
+		}
+		for _, indexes := range referencePattern.FindAllSubmatchIndex(d.source[start:end], -1) {
+			if offset := start + indexes[0]; offset > 0 && d.source[offset-1] == '\\' {
+				continue
+			}
+
+			label := d.source[start+indexes[4] : start+indexes[5]]
+			if len(bytes.TrimSpace(label)) == 0 {
+				label = d.source[start+indexes[2] : start+indexes[3]]
+			}
+			if _, found := d.definitions[util.ToLinkReference(label)]; found {
+				continue
+			}
+
+			raw := string(d.source[start+indexes[0] : start+indexes[1]])
+			t := d.token(string(label), raw, start+indexes[0])
+			t.Kind = UndefinedReference
+			result = append(result, t)
+		}
+		start, end = -1, -1
+	}
+
+	ast.Walk(d.root, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.Text:
+			if start >= 0 && (n.Segment.Start < end || len(bytes.TrimSpace(d.source[end:n.Segment.Start])) > 0) {
+				flush()
+			}
+			if start < 0 {
+				start = n.Segment.Start
+			}
+			end = n.Segment.Stop
+		case *ast.Link, *ast.Image, *ast.AutoLink, *ast.CodeSpan, *ast.RawHTML:
+			flush()
+			return ast.WalkSkipChildren, nil
+		default:
+			if node.Type() == ast.TypeBlock {
+				flush()
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	flush()
+	return result
+}
+
+// unusedDefinitions returns link definitions which no link refers to
+func (d *document) unusedDefinitions() []token {
+	var result []token
+	for key, definition := range d.definitions {
+		if d.used[key] {
+			continue
+		}
+		t := d.token(definition.label, definition.text, definition.offset)
+		t.Kind = UnusedDefinition
+		result = append(result, t)
+	}
+	return result
+}
+
+// headers returns headings and HTML elements with the id, or anchors with the name, in the document
+func (d *document) headers() Headers {
+	var result Headers
+	ast.Walk(d.root, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.Heading:
+			header := newHeader(string(n.Text(d.source)))
+			header.Origin = SetextHeader
+			if n.Lines().Len() > 0 {
+				start := n.Lines().At(0).Start
+				header.Line, _ = d.position(start)
+				if line := d.source[d.lineStarts[header.Line-1]:start]; bytes.Contains(line, []byte("#")) {
+					header.Origin = ATXHeader
+				}
+			} else {
+				// the empty heading can be only ATX, e.g. "#"
+				header.Origin = ATXHeader
+			}
+			result = append(result, header)
+			return ast.WalkSkipChildren, nil
+		case *ast.HTMLBlock:
+			segments := n.Lines()
+			if n.HasClosure() {
+				segments.Append(n.ClosureLine)
+			}
+			result = append(result, d.htmlAnchors(segments)...)
+		case *ast.RawHTML:
+			result = append(result, d.htmlAnchors(n.Segments)...)
+		}
+		return ast.WalkContinue, nil
+	})
+	return result
+}
+
+// directives returns directives in HTML comments, such as <!-- milv-disable -->
+func (d *document) directives() []Directive {
+	var result []Directive
+	ast.Walk(d.root, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		var segments *text.Segments
+		switch n := node.(type) {
+		case *ast.HTMLBlock:
+			if n.HTMLBlockType != ast.HTMLBlockType2 {
+				return ast.WalkContinue, nil
+			}
+			segments = n.Lines()
+			if n.HasClosure() {
+				segments.Append(n.ClosureLine)
+			}
+		case *ast.RawHTML:
+			segments = n.Segments
+		default:
+			return ast.WalkContinue, nil
+		}
+		if segments.Len() == 0 {
+			return ast.WalkContinue, nil
+		}
+
+		start := segments.At(0).Start
+		raw := d.source[start:segments.At(segments.Len()-1).Stop]
+		if !bytes.HasPrefix(raw, []byte("<!--")) {
+			return ast.WalkContinue, nil
+		}
+		// the terminator is looked for after the opener, which ends with -- as well, such as in <!-->;
+		// the comment without the terminator isn't the directive
+		end := bytes.Index(raw[len("<!--"):], []byte("-->"))
+		if end < 0 {
+			return ast.WalkContinue, nil
+		}
+		end += len("<!--")
+		line, _ := d.position(start + end)
+		if directive, found := commentDirective(string(raw[len("<!--"):end]), line); found {
+			result = append(result, directive)
+		}
+		return ast.WalkContinue, nil
+	})
+	return result
+}
+
+// htmlAnchors returns HTML headings, such as <h2>Title</h2>, and HTML elements which can be linked,
+// such as <a name="anchor"> or <div id="anchor">
+func (d *document) htmlAnchors(segments *text.Segments) Headers {
+	if segments.Len() == 0 {
+		return nil
+	}
+
+	var result Headers
+	// heading is the index of the HTML heading which text is collected
+	heading := -1
+	var headingTag string
+	var headingText strings.Builder
+
+	start := segments.At(0).Start
+	raw := d.source[start:segments.At(segments.Len()-1).Stop]
+	z := html.NewTokenizer(bytes.NewReader(raw))
+	offset := start
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		length := len(z.Raw())
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			t := z.Token()
+			line, _ := d.position(offset)
+			if heading < 0 && tt == html.StartTagToken && htmlHeadingPattern.MatchString(t.Data) {
+				heading, headingTag = len(result), t.Data
+				headingText.Reset()
+				result = append(result, Header{ID: getId(t), Origin: HTMLHeader, Line: line})
+			} else if id := getId(t); id != "" {
+				result = append(result, Header{ID: id, Origin: AnchorHeader, Line: line})
+			}
+		case html.TextToken:
+			if heading >= 0 {
+				headingText.Write(z.Text())
+			}
+		case html.EndTagToken:
+			if heading >= 0 && z.Token().Data == headingTag {
+				result[heading].Text = strings.TrimSpace(headingText.String())
+				heading = -1
+			}
+		}
+		offset += length
+	}
+
+	if heading >= 0 {
+		result[heading].Text = strings.TrimSpace(headingText.String())
+	}
+	return result
+}
+
+// linkToken finds the link in the source. The parser doesn't keep the position of inline nodes,
+// so it's found from the position of the link text.
+func (d *document) linkToken(node ast.Node, destination string, image bool) token {
+	start, nested := textStart(node)
+	if textOffset := start; start >= 0 {
+		// the bracket of the link is before brackets of links and images its text starts with
+		for i := 0; i <= nested && start >= d.cursor; i++ {
+			start = d.cursor + bytes.LastIndexByte(d.source[d.cursor:start], '[')
+		}
+		// brackets which aren't links, such as [[]()[0](), can put the text before the cursor,
+		// then the link starts at its text
+		if start < d.cursor {
+			start = textOffset
+			if start > 0 && d.source[start-1] == '[' {
+				start--
+			}
+		}
+	} else if i := bytes.IndexByte(d.source[d.cursor:], '['); i >= 0 {
+		start = d.cursor + i
+	} else {
+		start = d.cursor
+	}
+	end, label := d.linkEnd(start)
+	if label != "" {
+		d.used[util.ToLinkReference([]byte(label))] = true
+	}
+	if image && start > 0 && d.source[start-1] == '!' {
+		start--
+	}
+
+	// links may be nested, e.g. the image in the link, so the next one is searched within this one
+	// and the search continues after it when the walk leaves the link
+	d.cursor = start + 1
+	d.ends = append(d.ends, end)
+
+	raw := string(d.source[start:end])
+	if destination == "" {
+		destination = raw
+	}
+	return d.token(destination, raw, start)
+}
+
+func (d *document) autoLinkToken(node *ast.AutoLink) token {
+	label := node.Label(d.source)
+	start := d.cursor
+	if i := bytes.Index(d.source[d.cursor:], label); i >= 0 {
+		start += i
+	}
+	end := start + len(label)
+	if start > 0 && d.source[start-1] == '<' && end < len(d.source) && d.source[end] == '>' {
+		start--
+		end++
+	}
+	d.cursor = end
+
+	return d.token(string(node.URL(d.source)), string(d.source[start:end]), start)
+}
+
+// linkEnd returns the offset after the link which text starts with the bracket at the given offset,
+// e.g. after `[text](destination "title")` or `[text][label]`, and the label if the link is the reference
+func (d *document) linkEnd(start int) (int, string) {
+	source := d.source
+	i, depth := start, 0
+	for ; i < len(source); i++ {
+		if source[i] == '\\' {
+			i++
+		} else if source[i] == '[' {
+			depth++
+		} else if source[i] == ']' {
+			depth--
+			if depth == 0 {
+				break
+			}
+		}
+	}
+	if i >= len(source) {
+		return len(source), ""
+	}
+	text := string(source[start+1 : i])
+	i++
+
+	if i < len(source) && source[i] == '(' {
+		for ; i < len(source); i++ {
+			switch source[i] {
+			case '\\':
+				i++
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					return i + 1, ""
+				}
+			}
+		}
+		return len(source), ""
+	}
+
+	if i < len(source) && source[i] == '[' {
+		if j := bytes.IndexByte(source[i:], ']'); j >= 0 {
+			if label := string(source[i+1 : i+j]); strings.TrimSpace(label) != "" {
+				return i + j + 1, label
+			}
+			return i + j + 1, text
+		}
+	}
+	return i, text
+}
+
+// htmlLinks returns URLs from attributes of HTML elements, such as <img src="..."> or <a href="...">
+func (d *document) htmlLinks(segments *text.Segments) []token {
+	if segments.Len() == 0 {
+		return nil
+	}
+
+	var result []token
+	start := segments.At(0).Start
+	raw := d.source[start:segments.At(segments.Len()-1).Stop]
+	z := html.NewTokenizer(bytes.NewReader(raw))
+	offset := start
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			return result
+		}
+		tag := z.Raw()
+		if tt == html.StartTagToken || tt == html.SelfClosingTagToken {
+			for _, attr := range z.Token().Attr {
+				if !htmlLinkAttributes[attr.Key] {
+					continue
+				}
+				for _, value := range htmlAttributeURLs(attr) {
+					attrStart, attrEnd := htmlAttributeRange(tag, attr.Key)
+					result = append(result, d.token(value, string(tag[attrStart:attrEnd]), offset+attrStart))
+				}
+			}
+		}
+		offset += len(tag)
+	}
+}
+
+// scanSegments returns tokens for all matches of the regex within the segments of the source
+func (d *document) scanSegments(re *regexp.Regexp, segments *text.Segments, match match) []token {
+	var result []token
+	for i := 0; i < segments.Len(); i++ {
+		segment := segments.At(i)
+		line, column := d.position(segment.Start)
+		value := strings.TrimRight(string(segment.Value(d.source)), "\r\n")
+		result = append(result, matchLine(re, value, line, column, match)...)
+	}
+	return result
+}
+
+func (d *document) token(value, raw string, offset int) token {
+	line, column := d.position(offset)
+	return token{
+		Value:  value,
+		Line:   line,
+		Column: column,
+		Text:   raw,
+	}
+}
+
+// position returns 1-based line and column, counted in runes, of the offset in the source
+func (d *document) position(offset int) (int, int) {
+	line := sort.Search(len(d.lineStarts), func(i int) bool {
+		return d.lineStarts[i] > offset
+	})
+	column := utf8.RuneCount(d.source[d.lineStarts[line-1]:offset]) + 1
+	return line, column
+}
+
+// htmlAttributeURLs returns URLs in the value of the attribute, e.g. all image candidates in srcset
+func htmlAttributeURLs(attr html.Attribute) []string {
+	var urls []string
+	values := []string{attr.Val}
+	if attr.Key == "srcset" {
+		values = strings.Split(attr.Val, ",")
+	}
+	for _, value := range values {
+		fields := strings.Fields(value)
+		if len(fields) > 0 {
+			urls = append(urls, fields[0])
+		}
+	}
+	return urls
+}
+
+// htmlAttributeRange returns the range of the attribute with its value in the raw tag
+func htmlAttributeRange(tag []byte, key string) (int, int) {
+	indexes := regexp.MustCompile(`(?i)\s` + regexp.QuoteMeta(key) + `\s*=\s*`).FindIndex(tag)
+	if indexes == nil {
+		return 0, len(tag)
+	}
+
+	start, end := indexes[0]+1, indexes[1]
+	if end < len(tag) && (tag[end] == '"' || tag[end] == '\'') {
+		if i := bytes.IndexByte(tag[end+1:], tag[end]); i >= 0 {
+			return start, end + i + 2
+		}
+		return start, len(tag)
+	}
+	for end < len(tag) && !strings.ContainsRune(" \t\r\n>", rune(tag[end])) {
+		end++
+	}
+	return start, end
+}
+
+// textStart returns the offset of the text of the node in the source or -1 if the text is empty,
+// with the number of links and images the text is nested in within the node
+func textStart(node ast.Node) (int, int) {
+	start, nested := -1, 0
+	ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		switch n := n.(type) {
+		case *ast.Text:
+			start = n.Segment.Start
+			return ast.WalkStop, nil
+		case *ast.Link, *ast.Image:
+			if entering && n != node {
+				nested++
+			} else if !entering && n != node {
+				nested--
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+	return start, nested
+}
+
+func childSegments(node ast.Node) *text.Segments {
+	segments := text.NewSegments()
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		if t, ok := child.(*ast.Text); ok {
+			segments.Append(t.Segment)
+		}
+	}
+	return segments
+}
@@ -0,0 +1,70 @@
+package pkg
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	// ES module statements of MDX, such as import Tabs from '@theme/Tabs'
+	mdxModulePattern = `^(import|export)\s`
+	// MDX comments, such as {/* comment */}
+	mdxCommentPattern = `(?s)\{/\*.*?\*/\}`
+)
+
+// mdxParser parses MDX files, such as Docusaurus pages, as markdown without ES module statements and comments.
+// JSX elements are parsed as HTML, so URLs in their href and src attributes are checked.
+type mdxParser struct {
+	*Parser
+}
+
+func mdxFormat(parser *Parser) SourceParser {
+	return mdxParser{parser}
+}
+
+func (p mdxParser) Links(basePath, content, dirPath string) Links {
+	return p.Parser.Links(basePath, removeMDXSyntax(content), dirPath)
+}
+
+func (p mdxParser) Headers(content string) Headers {
+	return p.Parser.Headers(removeMDXSyntax(content))
+}
+
+// Directives returns directives in MDX comments, such as {/* milv-disable */}, and in HTML comments.
+// MDX comments are written as HTML comments, so comments in code blocks and code spans are skipped the same way.
+func (p mdxParser) Directives(content string) []Directive {
+	content = regexp.MustCompile(mdxCommentPattern).ReplaceAllStringFunc(content, func(comment string) string {
+		return "<!--" + comment[len("{/*"):len(comment)-len("*/}")] + "-->"
+	})
+	return p.Parser.Directives(content)
+}
+
+// removeMDXSyntax keeps new lines of the removed text, so lines of links don't change
+func removeMDXSyntax(content string) string {
+	blank := func(text string) string {
+		return strings.Repeat("\n", strings.Count(text, "\n"))
+	}
+
+	content = regexp.MustCompile(mdxCommentPattern).ReplaceAllStringFunc(content, blank)
+
+	lines := strings.Split(content, "\n")
+	module := regexp.MustCompile(mdxModulePattern)
+	inCode, inModule := false, false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+		}
+		if inCode {
+			continue
+		}
+		if module.MatchString(line) {
+			inModule = true
+		}
+		if inModule {
+			// the statement ends with the blank line
+			inModule = strings.TrimSpace(line) != ""
+			lines[i] = ""
+		}
+	}
+	return strings.Join(lines, "\n")
+}
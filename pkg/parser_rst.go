@@ -0,0 +1,150 @@
+package pkg
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// rstLinkPattern catches, in this order: inline hyperlinks `text <url>`_, directives with paths,
+	// such as .. image:: path, hyperlink targets .. _name: url and standalone URLs
+	rstLinkPattern = "`[^`<]*<([^>`]+)>`__?" +
+		`|^\s*\.\. (?:image|figure|include|literalinclude)::\s+(\S+)` +
+		`|^\s*\.\. _[^:]+:\s+(\S+)\s*$` +
+		`|\bhttps?://[^\s<>` + "`" + `]*[^\s<>` + "`" + `.,;:!?'")\]]`
+	// rstTargetPattern is the internal hyperlink target, such as .. _installation:
+	rstTargetPattern = `^\s*\.\. _([^:` + "`" + `]+):\s*$`
+	// rstCommentPattern is the comment with the directive, such as .. milv-disable
+	rstCommentPattern = `^\s*\.\.\s+(.*)$`
+	// rstCodePattern starts the code directive or the literal block, which follows the paragraph ending with ::
+	rstCodePattern = `^\s*\.\. (?:code|code-block|sourcecode)::|^\s*([^.\s]|\.[^.]).*::\s*$|^\s*::\s*$`
+)
+
+// rstParser parses reStructuredText files
+type rstParser struct {
+	*Parser
+}
+
+func rstFormat(parser *Parser) SourceParser {
+	return rstParser{parser}
+}
+
+func (p rstParser) Links(basePath, content, dirPath string) Links {
+	re := regexp.MustCompile(rstLinkPattern)
+
+	var tokens []token
+	for i, line := range p.lines(content) {
+		tokens = append(tokens, matchLine(re, line, i+1, 1, func(matches []string) string {
+			for _, value := range matches[1:] {
+				if value != "" {
+					return value
+				}
+			}
+			return matches[0]
+		})...)
+	}
+
+	// the target referring to another target, such as .. _docs: python_, isn't the link
+	links := tokens[:0]
+	for _, token := range tokens {
+		if !strings.HasSuffix(token.Value, "_") {
+			links = append(links, token)
+		}
+	}
+	return p.extractLinks(basePath, links, dirPath)
+}
+
+// Headers returns section titles, underlined and optionally overlined with punctuation characters,
+// and internal hyperlink targets. Their IDs are generated the same way as docutils does.
+func (p rstParser) Headers(content string) Headers {
+	target := regexp.MustCompile(rstTargetPattern)
+	lines := p.lines(content)
+
+	var headers Headers
+	for i, line := range lines {
+		if matches := target.FindStringSubmatch(line); matches != nil {
+			headers = append(headers, Header{ID: docutilsID(matches[1]), Origin: AnchorHeader, Line: i + 1})
+			continue
+		}
+
+		text := strings.TrimSpace(line)
+		if text == "" || isRSTAdornment(line) || i+1 >= len(lines) || !isRSTAdornment(lines[i+1]) ||
+			utf8.RuneCountInString(strings.TrimSpace(lines[i+1])) < utf8.RuneCountInString(text) {
+			continue
+		}
+		headers = append(headers, Header{Text: text, ID: docutilsID(text), Origin: SectionHeader, Line: i + 1})
+	}
+	return headers
+}
+
+// Directives returns directives in comments, such as .. milv-disable. Comments in literal blocks are skipped
+func (p rstParser) Directives(content string) []Directive {
+	comment := regexp.MustCompile(rstCommentPattern)
+	code := rstParser{&Parser{}}
+
+	var directives []Directive
+	for i, line := range code.lines(content) {
+		if matches := comment.FindStringSubmatch(line); matches != nil {
+			if directive, found := commentDirective(matches[1], i+1); found {
+				directives = append(directives, directive)
+			}
+		}
+	}
+	return directives
+}
+
+// lines returns lines of the file, lines of literal blocks are empty unless links in code blocks are checked
+func (p rstParser) lines(content string) []string {
+	lines := strings.Split(content, "\n")
+	if p.CodeBlocks {
+		return lines
+	}
+
+	code := regexp.MustCompile(rstCodePattern)
+	codeIndent := -1
+	for i, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if codeIndent >= 0 {
+			// the literal block ends with the first line which isn't indented more than the start of the block
+			if strings.TrimSpace(line) == "" || indent > codeIndent {
+				lines[i] = ""
+				continue
+			}
+			codeIndent = -1
+		}
+		if code.MatchString(line) {
+			codeIndent = indent
+		}
+	}
+	return lines
+}
+
+// isRSTAdornment checks if the line consists of one repeated punctuation character, such as ===== or -----
+func isRSTAdornment(line string) bool {
+	line = strings.TrimRight(line, " \t\r")
+	if len(line) < 2 {
+		return false
+	}
+	for _, r := range line {
+		if r != rune(line[0]) || !strings.ContainsRune("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// docutilsID reproduces make_id of docutils: lowercase letters and digits are kept,
+// other characters become hyphens, and leading digits and hyphens are removed
+func docutilsID(name string) string {
+	var id strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			id.WriteRune(r)
+		} else if !strings.HasSuffix(id.String(), "-") {
+			id.WriteRune('-')
+		}
+	}
+	return strings.TrimRight(strings.TrimLeft(id.String(), "-0123456789"), "-")
+}
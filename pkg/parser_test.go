@@ -17,14 +17,23 @@ func TestParser(t *testing.T) {
 			Link{
 				AbsPath: "https://twitter.com",
 				TypeOf:  ExternalLink,
+				Line:    7,
+				Column:  19,
+				Text:    "[Twitter](https://twitter.com)",
 			},
 			Link{
 				AbsPath: "https://github.com",
 				TypeOf:  ExternalLink,
+				Line:    9,
+				Column:  18,
+				Text:    "[Github](https://github.com)",
 			},
 			Link{
 				AbsPath: "https://httpbin.org/status/404",
 				TypeOf:  ExternalLink,
+				Line:    11,
+				Column:  29,
+				Text:    "[Link](https://httpbin.org/status/404)",
 			},
 		}
 
@@ -44,21 +53,33 @@ func TestParser(t *testing.T) {
 				AbsPath: "test-markdowns/external_links.md",
 				RelPath: "../external_links.md",
 				TypeOf:  InternalLink,
+				Line:    7,
+				Column:  1,
+				Text:    "[../external_links.md](../external_links.md)",
 			},
 			Link{
 				AbsPath: "test-markdowns/sub_path/sub_sub_path/without_links.md",
 				RelPath: "sub_sub_path/without_links.md",
 				TypeOf:  InternalLink,
+				Line:    9,
+				Column:  1,
+				Text:    "[sub_sub_path/without_links.md](sub_sub_path/without_links.md)",
 			},
 			Link{
 				AbsPath: "test-markdowns/sub_path/absolute_path.md",
 				RelPath: "absolute_path.md",
 				TypeOf:  InternalLink,
+				Line:    11,
+				Column:  1,
+				Text:    "[absolute_path.md](absolute_path.md)",
 			},
 			Link{
 				AbsPath: "test-markdowns/sub_path/invalid.md",
 				RelPath: "invalid.md",
 				TypeOf:  InternalLink,
+				Line:    13,
+				Column:  1,
+				Text:    "[Invalid internal link](invalid.md)",
 			},
 		}
 
@@ -77,38 +98,65 @@ func TestParser(t *testing.T) {
 			Link{
 				AbsPath: "https://github.com",
 				TypeOf:  ExternalLink,
+				Line:    13,
+				Column:  17,
+				Text:    "[link](https://github.com)",
 			},
 			Link{
 				AbsPath: "https://github.com",
 				TypeOf:  ExternalLink,
+				Line:    21,
+				Column:  10,
+				Text:    "[strange](https://github.com)",
 			},
 			Link{
 				RelPath: "#first-header",
 				TypeOf:  HashInternalLink,
+				Line:    27,
+				Column:  24,
+				Text:    "[link](#first-header)",
 			},
 			Link{
 				RelPath: "#second-header",
 				TypeOf:  HashInternalLink,
+				Line:    29,
+				Column:  25,
+				Text:    "[link](#second-header)",
 			},
 			Link{
 				RelPath: "#third-header",
 				TypeOf:  HashInternalLink,
+				Line:    31,
+				Column:  32,
+				Text:    "[link](#third-header)",
 			},
 			Link{
 				RelPath: "#header",
 				TypeOf:  HashInternalLink,
+				Line:    33,
+				Column:  29,
+				Text:    "[link](#header)",
 			},
 			Link{
 				RelPath: "#header-with-block",
 				TypeOf:  HashInternalLink,
+				Line:    35,
+				Column:  29,
+				Text:    "[link](#header-with-block)",
 			},
 			Link{
 				RelPath: "#header-with-link",
 				TypeOf:  HashInternalLink,
+				Line:    37,
+				Column:  28,
+				Text:    "[link](#header-with-link)",
 			},
 			Link{
 				RelPath: "#very-strange-header-really-people-create-headers-look-like-this",
 				TypeOf:  HashInternalLink,
+				Line:    39,
+				Column:  31,
+				Text:    "[link](#very-strange-header-really-people-create-headers-look-like-this)",
 			},
 		}
 
@@ -122,14 +170,14 @@ func TestParser(t *testing.T) {
 		content, err := readMarkdown("test-markdowns/hash_internal_links.md")
 		require.NoError(t, err)
 
-		expected := []string{
-			"First Header",
-			"Second Header",
-			"Third Header",
-			"Header with link",
-			"Header with block",
-			"Very strange header really people create headers look like this",
-			"Links",
+		expected := Headers{
+			Header{Text: "First Header", Origin: ATXHeader, Line: 1},
+			Header{Text: "Second Header", Origin: ATXHeader, Line: 5},
+			Header{Text: "Third Header", Origin: ATXHeader, Line: 9},
+			Header{Text: "Header with link", Origin: ATXHeader, Line: 13},
+			Header{Text: "Header with block", Origin: ATXHeader, Line: 17},
+			Header{Text: "Very strange header (really, people create headers look like this)", Origin: ATXHeader, Line: 21},
+			Header{Text: "Links", Origin: ATXHeader, Line: 25},
 		}
 
 		parser := &Parser{}
@@ -149,12 +197,377 @@ func TestParser(t *testing.T) {
 				AbsPath: "test-markdowns/external_links.md",
 				RelPath: "/external_links.md",
 				TypeOf:  InternalLink,
+				Line:    3,
+				Column:  28,
+				Text:    "[/external_links.md](/external_links.md)",
 			},
 		}
 
 		parser := &Parser{}
 		result := parser.Links(basePath, content, dirPath)
 
+		assert.Equal(t, expected, result)
+	})
+	t.Run("Dense Lines", func(t *testing.T) {
+		dirPath := "test-markdowns"
+		content, err := readMarkdown("test-markdowns/dense_links.md")
+		require.NoError(t, err)
+
+		expected := Links{
+			Link{
+				AbsPath: "https://github.com/kyma-incubator/milv",
+				TypeOf:  ExternalLink,
+				Line:    5,
+				Column:  10,
+				Text:    "[repository](https://github.com/kyma-incubator/milv)",
+			},
+			Link{
+				RelPath: "./external_links.md#links",
+				AbsPath: "test-markdowns/external_links.md#links",
+				TypeOf:  InternalLink,
+				Line:    5,
+				Column:  65,
+				Text:    "[docs](./external_links.md#links)",
+			},
+			Link{
+				AbsPath: "https://kyma-project.io",
+				TypeOf:  ExternalLink,
+				Line:    6,
+				Column:  10,
+				Text:    "https://kyma-project.io",
+			},
+			Link{
+				AbsPath: "https://github.com/kyma-project",
+				TypeOf:  ExternalLink,
+				Line:    6,
+				Column:  36,
+				Text:    "https://github.com/kyma-project",
+			},
+			Link{
+				RelPath: "#dense-links",
+				TypeOf:  HashInternalLink,
+				Line:    8,
+				Column:  5,
+				Text:    "[first](#dense-links)",
+			},
+			Link{
+				RelPath: "internal_links.md",
+				AbsPath: "test-markdowns/internal_links.md",
+				TypeOf:  InternalLink,
+				Line:    8,
+				Column:  28,
+				Text:    "[second](internal_links.md)",
+			},
+			Link{
+				AbsPath: "https://github.com",
+				TypeOf:  ExternalLink,
+				Line:    8,
+				Column:  60,
+				Text:    "[third](https://github.com)",
+			},
+			Link{
+				RelPath: "./images/logo.png",
+				AbsPath: "test-markdowns/images/logo.png",
+				TypeOf:  InternalLink,
+				Line:    10,
+				Column:  1,
+				Text:    "![logo](./images/logo.png)",
+			},
+			Link{
+				AbsPath: "https://github.com/kyma-incubator/milv",
+				TypeOf:  ExternalLink,
+				Line:    10,
+				Column:  28,
+				Text:    "[![badge](https://img.shields.io/badge.svg)](https://github.com/kyma-incubator/milv)",
+			},
+			Link{
+				AbsPath: "https://img.shields.io/badge.svg",
+				TypeOf:  ExternalLink,
+				Line:    10,
+				Column:  29,
+				Text:    "![badge](https://img.shields.io/badge.svg)",
+			},
+			Link{
+				AbsPath: "https://twitter.com",
+				TypeOf:  ExternalLink,
+				Line:    10,
+				Column:  117,
+				Text:    "https://twitter.com",
+			},
+		}
+
+		parser := &Parser{}
+		result := parser.Links("", content, dirPath)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("Regex Parser", func(t *testing.T) {
+		dirPath := "test-markdowns"
+		content, err := readMarkdown("test-markdowns/dense_links.md")
+		require.NoError(t, err)
+
+		expected := Links{
+			Link{
+				RelPath: "./images/logo.png",
+				AbsPath: "test-markdowns/images/logo.png",
+				TypeOf:  InternalLink,
+				Line:    10,
+				Column:  1,
+				Text:    "![logo](./images/logo.png)",
+			},
+			Link{
+				AbsPath: "https://img.shields.io/badge.svg",
+				TypeOf:  ExternalLink,
+				Line:    10,
+				Column:  29,
+				Text:    "![badge](https://img.shields.io/badge.svg)",
+			},
+			Link{
+				AbsPath: "https://github.com/kyma-incubator/milv",
+				TypeOf:  ExternalLink,
+				Line:    10,
+				Column:  73,
+				Text:    "https://github.com/kyma-incubator/milv",
+			},
+			Link{
+				AbsPath: "https://twitter.com",
+				TypeOf:  ExternalLink,
+				Line:    10,
+				Column:  117,
+				Text:    "https://twitter.com",
+			},
+		}
+
+		parser := &Parser{Regex: true}
+		result := parser.Links("", content, dirPath)
+
+		assert.Equal(t, expected, result[len(result)-4:])
+	})
+
+	t.Run("Code Blocks", func(t *testing.T) {
+		content, err := readMarkdown("test-markdowns/code_blocks.md")
+		require.NoError(t, err)
+
+		//GIVEN
+		parser := &Parser{}
+		parserWithCodeBlocks := &Parser{CodeBlocks: true}
+
+		//WHEN
+		links := parser.Links("", content, "test-markdowns")
+		linksWithCodeBlocks := parserWithCodeBlocks.Links("", content, "test-markdowns")
+
+		//THEN
+		assert.Equal(t, Links{
+			Link{
+				AbsPath: "https://github.com",
+				TypeOf:  ExternalLink,
+				Line:    3,
+				Column:  31,
+				Text:    "<https://github.com>",
+			},
+		}, links)
+		assert.Equal(t, Links{
+			Link{
+				AbsPath: "https://github.com",
+				TypeOf:  ExternalLink,
+				Line:    3,
+				Column:  31,
+				Text:    "<https://github.com>",
+			},
+			Link{
+				AbsPath: "https://kyma-project.io",
+				TypeOf:  ExternalLink,
+				Line:    3,
+				Column:  65,
+				Text:    "https://kyma-project.io",
+			},
+			Link{
+				RelPath: "internal_links.md",
+				AbsPath: "test-markdowns/internal_links.md",
+				TypeOf:  InternalLink,
+				Line:    6,
+				Column:  5,
+				Text:    "[internal](internal_links.md)",
+			},
+		}, linksWithCodeBlocks)
+	})
+
+	t.Run("Setext Headers", func(t *testing.T) {
+		content, err := readMarkdown("test-markdowns/code_blocks.md")
+		require.NoError(t, err)
+
+		parser := &Parser{}
+		result := parser.Headers(content)
+
+		assert.Equal(t, Headers{
+			Header{Text: "Code blocks", Origin: ATXHeader, Line: 1},
+			Header{Text: "Not a header", Origin: SetextHeader, Line: 10},
+		}, result)
+	})
+	t.Run("Reference Links", func(t *testing.T) {
+		dirPath := "test-markdowns"
+		content, err := readMarkdown("test-markdowns/reference_links.md")
+		require.NoError(t, err)
+
+		expected := Links{
+			Link{
+				AbsPath: "https://github.com",
+				TypeOf:  ExternalLink,
+				Line:    3,
+				Column:  9,
+				Text:    "[Github][github]",
+			},
+			Link{
+				AbsPath: "https://twitter.com",
+				TypeOf:  ExternalLink,
+				Line:    3,
+				Column:  27,
+				Text:    "[Twitter][]",
+			},
+			Link{
+				RelPath: "external_links.md",
+				AbsPath: "test-markdowns/external_links.md",
+				TypeOf:  InternalLink,
+				Line:    3,
+				Column:  43,
+				Text:    "[external links]",
+			},
+			Link{
+				RelPath: "undefined",
+				TypeOf:  ReferenceLink,
+				Line:    5,
+				Column:  9,
+				Text:    "[undefined reference][undefined]",
+				Result: LinkResult{
+					Status:  false,
+					Message: "The reference isn't defined in this file",
+					Kind:    UndefinedReference,
+				},
+			},
+			Link{
+				RelPath: "undefined image",
+				TypeOf:  ReferenceLink,
+				Line:    5,
+				Column:  46,
+				Text:    "![undefined image][]",
+				Result: LinkResult{
+					Status:  false,
+					Message: "The reference isn't defined in this file",
+					Kind:    UndefinedReference,
+				},
+			},
+			Link{
+				RelPath: "unused",
+				TypeOf:  ReferenceLink,
+				Line:    12,
+				Column:  1,
+				Text:    "[unused]: https://kyma-project.io",
+				Result: LinkResult{
+					Status:  false,
+					Message: "The link definition isn't used in this file",
+					Kind:    UnusedDefinition,
+				},
+			},
+		}
+
+		parser := &Parser{}
+		result := parser.Links("", content, dirPath)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("HTML Links", func(t *testing.T) {
+		dirPath := "test-markdowns"
+		content, err := readMarkdown("test-markdowns/html_links.md")
+		require.NoError(t, err)
+
+		expected := Links{
+			Link{
+				RelPath: "images/logo.png",
+				AbsPath: "test-markdowns/images/logo.png",
+				TypeOf:  InternalLink,
+				Line:    4,
+				Column:  8,
+				Text:    `src="images/logo.png"`,
+			},
+			Link{
+				AbsPath: "https://github.com/kyma-incubator/milv",
+				TypeOf:  ExternalLink,
+				Line:    5,
+				Column:  6,
+				Text:    `href="https://github.com/kyma-incubator/milv"`,
+			},
+			Link{
+				RelPath: "images/missing.png",
+				AbsPath: "test-markdowns/images/missing.png",
+				TypeOf:  InternalLink,
+				Line:    5,
+				Column:  57,
+				Text:    `src='images/missing.png'`,
+			},
+			Link{
+				RelPath: "external_links.md#links",
+				AbsPath: "test-markdowns/external_links.md#links",
+				TypeOf:  InternalLink,
+				Line:    8,
+				Column:  11,
+				Text:    `href="external_links.md#links"`,
+			},
+			Link{
+				RelPath: "images/logo.png",
+				AbsPath: "test-markdowns/images/logo.png",
+				TypeOf:  InternalLink,
+				Line:    10,
+				Column:  1,
+				Text:    `![Logo](images/logo.png "Logo")`,
+			},
+			Link{
+				RelPath: "images/logo.png?raw=true",
+				AbsPath: "test-markdowns/images/logo.png?raw=true",
+				TypeOf:  InternalLink,
+				Line:    10,
+				Column:  37,
+				Text:    "![Raw logo](images/logo.png?raw=true)",
+			},
+		}
+
+		parser := &Parser{}
+		result := parser.Links("", content, dirPath)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("Unbalanced Brackets", func(t *testing.T) {
+		content := "[[]()[0](a.md)\n"
+
+		parser := &Parser{}
+		var result Links
+		require.NotPanics(t, func() {
+			result = parser.Links("", content, ".")
+		})
+
+		require.Len(t, result, 2)
+		assert.Equal(t, "a.md", result[1].RelPath)
+		assert.Equal(t, 1, result[1].Line)
+		assert.Equal(t, 6, result[1].Column)
+		assert.Equal(t, "[0](a.md)", result[1].Text)
+	})
+	t.Run("Explicit IDs and HTML Anchors", func(t *testing.T) {
+		content, err := readMarkdown("test-markdowns/custom_anchors.md")
+		require.NoError(t, err)
+
+		expected := Headers{
+			Header{Text: "Custom anchors", Origin: ATXHeader, Line: 1},
+			Header{Text: "Installation {#setup}", ID: "setup", Origin: ATXHeader, Line: 3},
+			Header{ID: "legacy-anchor", Origin: AnchorHeader, Line: 5},
+			Header{ID: "box", Origin: AnchorHeader, Line: 6},
+			Header{ID: "inline-anchor", Origin: AnchorHeader, Line: 6},
+			Header{Text: "Overview", Origin: SetextHeader, Line: 10},
+			Header{Text: "HTML heading", Origin: HTMLHeader, Line: 13},
+			Header{Text: "Configuration", ID: "config", Origin: HTMLHeader, Line: 14},
+		}
+
+		parser := &Parser{}
+		result := parser.Headers(content)
+
 		assert.Equal(t, expected, result)
 	})
 }
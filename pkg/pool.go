@@ -0,0 +1,39 @@
+package pkg
+
+import "sync"
+
+type workerPool struct {
+	slots chan struct{}
+}
+
+func newWorkerPool(size int) *workerPool {
+	if size < 1 {
+		size = 1
+	}
+	return &workerPool{slots: make(chan struct{}, size)}
+}
+
+// Run calls fn for every index in [0, n) and blocks until all calls are done.
+// At most size calls run at the same time, even when the pool is shared.
+func (p *workerPool) Run(n int, fn func(i int)) {
+	if p == nil {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		p.slots <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-p.slots
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
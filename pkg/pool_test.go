@@ -0,0 +1,65 @@
+package pkg
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPool(t *testing.T) {
+	t.Run("Calls function for every index", func(t *testing.T) {
+		//GIVEN
+		pool := newWorkerPool(4)
+		results := make([]int, 10)
+
+		//WHEN
+		pool.Run(len(results), func(i int) {
+			results[i] = i * i
+		})
+
+		//THEN
+		assert.Equal(t, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, results)
+	})
+
+	t.Run("Does not exceed pool size", func(t *testing.T) {
+		//GIVEN
+		pool := newWorkerPool(3)
+		var mu sync.Mutex
+		running, maxRunning := 0, 0
+
+		//WHEN
+		pool.Run(20, func(i int) {
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+		})
+
+		//THEN
+		assert.True(t, maxRunning <= 3)
+	})
+
+	t.Run("Nil pool runs sequentially", func(t *testing.T) {
+		//GIVEN
+		var pool *workerPool
+		var order []int
+
+		//WHEN
+		pool.Run(3, func(i int) {
+			order = append(order, i)
+		})
+
+		//THEN
+		assert.Equal(t, []int{0, 1, 2}, order)
+	})
+}
@@ -0,0 +1,160 @@
+package pkg
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type RateLimitConfig struct {
+	RequestsPerSecond float64         `yaml:"requests-per-second"`
+	MaxInFlight       int             `yaml:"max-in-flight"`
+	MaxRetryAfter     time.Duration   `yaml:"max-retry-after"`
+	Hosts             []HostRateLimit `yaml:"hosts"`
+}
+
+type HostRateLimit struct {
+	Host              string  `yaml:"host"`
+	RequestsPerSecond float64 `yaml:"requests-per-second"`
+	MaxInFlight       int     `yaml:"max-in-flight"`
+}
+
+type HostLimiter struct {
+	config RateLimitConfig
+	mu     sync.Mutex
+	hosts  map[string]*hostState
+}
+
+type hostState struct {
+	interval time.Duration
+	next     time.Time
+	slots    chan struct{}
+}
+
+func NewHostLimiter(config RateLimitConfig) *HostLimiter {
+	return &HostLimiter{
+		config: config,
+		hosts:  map[string]*hostState{},
+	}
+}
+
+// Acquire blocks until a request to the host is allowed and returns a function
+// which must be called when the request is finished.
+func (l *HostLimiter) Acquire(host string) func() {
+	if l == nil {
+		return func() {}
+	}
+
+	state := l.state(host)
+	if state.slots != nil {
+		state.slots <- struct{}{}
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	start := now
+	if state.next.After(now) {
+		start = state.next
+	}
+	state.next = start.Add(state.interval)
+	l.mu.Unlock()
+
+	time.Sleep(start.Sub(now))
+
+	return func() {
+		if state.slots != nil {
+			<-state.slots
+		}
+	}
+}
+
+// Delay holds back all requests to the host until the given time,
+// e.g. when the server responds with the Retry-After header.
+func (l *HostLimiter) Delay(host string, until time.Time) {
+	if l == nil {
+		return
+	}
+
+	if l.config.MaxRetryAfter > 0 && time.Until(until) > l.config.MaxRetryAfter {
+		until = time.Now().Add(l.config.MaxRetryAfter)
+	}
+
+	state := l.state(host)
+	l.mu.Lock()
+	if until.After(state.next) {
+		state.next = until
+	}
+	l.mu.Unlock()
+}
+
+func (l *HostLimiter) state(host string) *hostState {
+	host = strings.ToLower(host)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if state, ok := l.hosts[host]; ok {
+		return state
+	}
+
+	requestsPerSecond, maxInFlight := l.hostLimits(host)
+	state := &hostState{}
+	if requestsPerSecond > 0 {
+		state.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	if maxInFlight > 0 {
+		state.slots = make(chan struct{}, maxInFlight)
+	}
+	l.hosts[host] = state
+	return state
+}
+
+// hostLimits returns limits of the host, fields which the limit of the host doesn't set are taken from global limits
+func (l *HostLimiter) hostLimits(host string) (float64, int) {
+	requestsPerSecond, maxInFlight := l.config.RequestsPerSecond, l.config.MaxInFlight
+	if hostLimit, found := findHostRateLimit(host, l.config.Hosts); found {
+		if hostLimit.RequestsPerSecond > 0 {
+			requestsPerSecond = hostLimit.RequestsPerSecond
+		}
+		if hostLimit.MaxInFlight > 0 {
+			maxInFlight = hostLimit.MaxInFlight
+		}
+	}
+	return requestsPerSecond, maxInFlight
+}
+
+// findHostRateLimit returns the limit for the host or for the closest parent domain of the host
+func findHostRateLimit(host string, limits []HostRateLimit) (HostRateLimit, bool) {
+	var result HostRateLimit
+	found := false
+	for _, limit := range limits {
+		domain := strings.ToLower(limit.Host)
+		if host != domain && !strings.HasSuffix(host, "."+domain) {
+			continue
+		}
+		if !found || len(limit.Host) > len(result.Host) {
+			result = limit
+			found = true
+		}
+	}
+	return result, found
+}
+
+// retryAfter parses the Retry-After header which contains either delay in seconds or HTTP date
+func retryAfter(header http.Header, now time.Time) (time.Time, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return date, true
+	}
+	return time.Time{}, false
+}
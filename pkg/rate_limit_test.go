@@ -0,0 +1,203 @@
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostLimiter(t *testing.T) {
+	t.Run("Requests per second", func(t *testing.T) {
+		//GIVEN
+		limiter := NewHostLimiter(RateLimitConfig{RequestsPerSecond: 20})
+		before := time.Now()
+
+		//WHEN
+		for i := 0; i < 3; i++ {
+			limiter.Acquire("github.com")()
+		}
+
+		//THEN
+		assert.True(t, time.Since(before) >= 100*time.Millisecond)
+	})
+
+	t.Run("Hosts are limited separately", func(t *testing.T) {
+		//GIVEN
+		limiter := NewHostLimiter(RateLimitConfig{RequestsPerSecond: 1})
+		before := time.Now()
+
+		//WHEN
+		limiter.Acquire("github.com")()
+		limiter.Acquire("twitter.com")()
+
+		//THEN
+		assert.True(t, time.Since(before) < 500*time.Millisecond)
+	})
+
+	t.Run("Max in flight", func(t *testing.T) {
+		//GIVEN
+		limiter := NewHostLimiter(RateLimitConfig{MaxInFlight: 2})
+		var mu sync.Mutex
+		running, maxRunning := 0, 0
+
+		//WHEN
+		newWorkerPool(10).Run(10, func(i int) {
+			release := limiter.Acquire("github.com")
+			defer release()
+
+			mu.Lock()
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+		})
+
+		//THEN
+		assert.Equal(t, 2, maxRunning)
+	})
+
+	t.Run("Domain specific limits", func(t *testing.T) {
+		//GIVEN
+		limits := []HostRateLimit{
+			{Host: "github.com", MaxInFlight: 4},
+			{Host: "api.github.com", MaxInFlight: 1},
+		}
+
+		//WHEN
+		apiLimit, apiFound := findHostRateLimit("api.github.com", limits)
+		rawLimit, rawFound := findHostRateLimit("raw.github.com", limits)
+		_, otherFound := findHostRateLimit("notgithub.com", limits)
+
+		//THEN
+		require.True(t, apiFound)
+		require.True(t, rawFound)
+		assert.False(t, otherFound)
+		assert.Equal(t, 1, apiLimit.MaxInFlight)
+		assert.Equal(t, 4, rawLimit.MaxInFlight)
+	})
+
+	t.Run("Host limits inherit global limits", func(t *testing.T) {
+		//GIVEN
+		limiter := NewHostLimiter(RateLimitConfig{
+			RequestsPerSecond: 5,
+			MaxInFlight:       8,
+			Hosts: []HostRateLimit{
+				{Host: "github.com", MaxInFlight: 1},
+				{Host: "twitter.com", RequestsPerSecond: 2},
+			},
+		})
+
+		//WHEN
+		githubRequests, githubInFlight := limiter.hostLimits("api.github.com")
+		twitterRequests, twitterInFlight := limiter.hostLimits("twitter.com")
+		otherRequests, otherInFlight := limiter.hostLimits("kyma-project.io")
+
+		//THEN
+		assert.Equal(t, 5.0, githubRequests)
+		assert.Equal(t, 1, githubInFlight)
+		assert.Equal(t, 2.0, twitterRequests)
+		assert.Equal(t, 8, twitterInFlight)
+		assert.Equal(t, 5.0, otherRequests)
+		assert.Equal(t, 8, otherInFlight)
+		assert.Equal(t, 200*time.Millisecond, limiter.state("api.github.com").interval)
+		assert.Equal(t, 1, cap(limiter.state("api.github.com").slots))
+	})
+
+	t.Run("Delay is capped", func(t *testing.T) {
+		//GIVEN
+		limiter := NewHostLimiter(RateLimitConfig{MaxRetryAfter: 50 * time.Millisecond})
+		before := time.Now()
+
+		//WHEN
+		limiter.Delay("github.com", before.Add(time.Hour))
+		limiter.Acquire("github.com")()
+
+		//THEN
+		elapsed := time.Since(before)
+		assert.True(t, elapsed >= 50*time.Millisecond)
+		assert.True(t, elapsed < time.Second)
+	})
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tcs := []struct {
+		Name     string
+		Value    string
+		Expected time.Time
+		Ok       bool
+	}{
+		{Name: "Seconds", Value: "120", Expected: now.Add(2 * time.Minute), Ok: true},
+		{Name: "HTTP date", Value: "Wed, 01 Jan 2020 12:05:00 GMT", Expected: now.Add(5 * time.Minute), Ok: true},
+		{Name: "Empty", Value: "", Ok: false},
+		{Name: "Invalid", Value: "soon", Ok: false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.Name, func(t *testing.T) {
+			//GIVEN
+			header := http.Header{}
+			header.Set("Retry-After", tc.Value)
+
+			//WHEN
+			result, ok := retryAfter(header, now)
+
+			//THEN
+			require.Equal(t, tc.Ok, ok)
+			assert.True(t, tc.Expected.Equal(result))
+		})
+	}
+}
+
+func TestValidatorHonorsRetryAfter(t *testing.T) {
+	//GIVEN
+	requestRepeats := 2
+	var mu sync.Mutex
+	calls := 0
+	svc := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if calls == 1 {
+			writer.Header().Set("Retry-After", "1")
+			writer.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer svc.Close()
+
+	waitMock := &waitMock{}
+	v := NewValidator(http.Client{}, waitMock)
+	v.limiter = NewHostLimiter(RateLimitConfig{MaxRetryAfter: time.Minute})
+	inputLink := Link{
+		TypeOf:  ExternalLink,
+		AbsPath: svc.URL,
+		Config: &LinkConfig{
+			RequestRepeats: &requestRepeats,
+		},
+	}
+	before := time.Now()
+
+	//WHEN
+	outLink, err := v.externalLink(inputLink)
+
+	//THEN
+	require.NoError(t, err)
+	assert.True(t, outLink.Result.Status)
+	assert.True(t, time.Since(before) >= time.Second)
+	waitMock.AssertNotCalled(t, "Wait")
+}
@@ -0,0 +1,143 @@
+package pkg
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// RedirectPolicy tells how the redirect of the external link is reported
+type RedirectPolicy string
+
+const (
+	// FailRedirect reports the redirected link as broken
+	FailRedirect RedirectPolicy = "fail"
+	// WarnRedirect reports the redirected link as valid with the warning severity
+	WarnRedirect RedirectPolicy = "warn"
+	// PassRedirect reports the redirected link as valid, the same as the link without redirects
+	PassRedirect RedirectPolicy = "pass"
+
+	// DefaultMaxRedirects is the number of redirects followed, the same as by default in net/http
+	DefaultMaxRedirects = 10
+)
+
+// RedirectConfig tells how many redirects of external links are followed and how permanent (301 and 308)
+// and temporary (302, 303 and 307) redirects are reported. Links with allow-redirect always pass.
+type RedirectConfig struct {
+	MaxHops   int            `yaml:"max-hops"`
+	Permanent RedirectPolicy `yaml:"permanent"`
+	Temporary RedirectPolicy `yaml:"temporary"`
+}
+
+// Redirect is the redirect response and the URL it points to
+type Redirect struct {
+	StatusCode int    `json:"statusCode"`
+	URL        string `json:"url"`
+}
+
+// redirectError stops following redirects, the kind of the failure tells why
+type redirectError struct {
+	kind    FailureKind
+	message string
+}
+
+func (e *redirectError) Error() string {
+	return e.message
+}
+
+func validateRedirectPolicies(config RedirectConfig) error {
+	for _, policy := range []RedirectPolicy{config.Permanent, config.Temporary} {
+		if policy != FailRedirect && policy != WarnRedirect && policy != PassRedirect {
+			return errors.Errorf("Unknown redirect policy %q", policy)
+		}
+	}
+	return nil
+}
+
+// followRedirects returns the CheckRedirect function of the client, which records redirects in the chain
+// and stops after the maximum number of hops or when the redirect leads to the URL which was already requested
+func (c RedirectConfig) followRedirects(chain *[]Redirect) func(req *http.Request, via []*http.Request) error {
+	maxHops := c.MaxHops
+	if maxHops <= 0 {
+		maxHops = DefaultMaxRedirects
+	}
+
+	return func(req *http.Request, via []*http.Request) error {
+		*chain = append(*chain, Redirect{StatusCode: req.Response.StatusCode, URL: req.URL.String()})
+		for _, previous := range via {
+			if previous.URL.String() == req.URL.String() {
+				urls := []string{via[0].URL.String()}
+				for _, redirect := range *chain {
+					urls = append(urls, redirect.URL)
+				}
+				return &redirectError{
+					kind:    RedirectLoop,
+					message: fmt.Sprintf("The website redirects in a loop: %s", strings.Join(urls, " -> ")),
+				}
+			}
+		}
+		if len(*chain) > maxHops {
+			return &redirectError{
+				kind:    TooManyRedirects,
+				message: fmt.Sprintf("The website redirects more than %d times", maxHops),
+			}
+		}
+		return nil
+	}
+}
+
+// apply reports the redirected link according to the policy of the strictest redirect in the chain
+func (c RedirectConfig) apply(result LinkResult, chain []Redirect) LinkResult {
+	if !result.Status || len(chain) == 0 {
+		return result
+	}
+
+	policy, kind := PassRedirect, FailureKind("")
+	for _, redirect := range chain {
+		redirectPolicy, redirectKind := c.Temporary, TemporaryRedirect
+		if isPermanentRedirect(redirect.StatusCode) {
+			redirectPolicy, redirectKind = c.Permanent, PermanentRedirect
+		}
+		if redirectPolicy == FailRedirect || redirectPolicy == WarnRedirect && policy == PassRedirect {
+			policy, kind = redirectPolicy, redirectKind
+		}
+	}
+	if policy == PassRedirect || policy == "" {
+		return result
+	}
+
+	var severity Severity
+	if policy == WarnRedirect {
+		severity = WarningSeverity
+	}
+	how := "temporarily"
+	if kind == PermanentRedirect {
+		how = "permanently"
+	}
+	return LinkResult{
+		Status:    policy == WarnRedirect,
+		Message:   fmt.Sprintf("The website redirects %s to %s", how, chain[len(chain)-1].URL),
+		Kind:      kind,
+		Severity:  severity,
+		Redirects: result.Redirects,
+	}
+}
+
+// permanentRedirect returns the final URL of the response, if all redirects to it are permanent
+func (r checkResult) permanentRedirect() (string, bool) {
+	if len(r.Redirects) == 0 || !r.isSuccess(false) {
+		return "", false
+	}
+	for _, redirect := range r.Redirects {
+		if !isPermanentRedirect(redirect.StatusCode) {
+			return "", false
+		}
+	}
+	return r.Redirects[len(r.Redirects)-1].URL, true
+}
+
+func isPermanentRedirect(statusCode int) bool {
+	return statusCode == http.StatusMovedPermanently || statusCode == http.StatusPermanentRedirect
+}
@@ -0,0 +1,128 @@
+package pkg
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedirects(t *testing.T) {
+	svc := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/permanent":
+			http.Redirect(writer, request, "/page", http.StatusMovedPermanently)
+		case "/temporary":
+			http.Redirect(writer, request, "/permanent", http.StatusTemporaryRedirect)
+		case "/loop":
+			http.Redirect(writer, request, "/back", http.StatusFound)
+		case "/back":
+			http.Redirect(writer, request, "/loop", http.StatusFound)
+		case "/1":
+			http.Redirect(writer, request, "/2", http.StatusPermanentRedirect)
+		case "/2":
+			http.Redirect(writer, request, "/3", http.StatusPermanentRedirect)
+		case "/3":
+			http.Redirect(writer, request, "/page", http.StatusPermanentRedirect)
+		default:
+			writer.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer svc.Close()
+
+	check := func(config RedirectConfig, links ...Link) []Link {
+		v := NewValidator(http.Client{}, &waitMock{})
+		v.pool = newWorkerPool(1)
+		v.redirects = config
+		return v.Links(links)
+	}
+
+	t.Run("Redirect Chain", func(t *testing.T) {
+		//GIVEN
+		link := Link{TypeOf: ExternalLink, AbsPath: svc.URL + "/temporary"}
+
+		//WHEN
+		result := check(RedirectConfig{}, link)
+
+		//THEN
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Result.Status)
+		assert.Equal(t, []Redirect{
+			{StatusCode: http.StatusTemporaryRedirect, URL: svc.URL + "/permanent"},
+			{StatusCode: http.StatusMovedPermanently, URL: svc.URL + "/page"},
+		}, result[0].Result.Redirects)
+	})
+
+	t.Run("Policies", func(t *testing.T) {
+		//GIVEN
+		config := RedirectConfig{Permanent: WarnRedirect, Temporary: FailRedirect}
+		links := []Link{
+			{TypeOf: ExternalLink, AbsPath: svc.URL + "/permanent"},
+			{TypeOf: ExternalLink, AbsPath: svc.URL + "/temporary"},
+			{TypeOf: ExternalLink, AbsPath: svc.URL + "/page"},
+		}
+
+		//WHEN
+		result := check(config, links...)
+
+		//THEN
+		require.Len(t, result, 3)
+		assert.True(t, result[0].Result.Status)
+		assert.Equal(t, WarningSeverity, result[0].Result.Severity)
+		assert.Equal(t, PermanentRedirect, result[0].Result.Kind)
+		assert.Equal(t, "The website redirects permanently to "+svc.URL+"/page", result[0].Result.Message)
+		assert.False(t, result[1].Result.Status)
+		assert.Equal(t, TemporaryRedirect, result[1].Result.Kind)
+		assert.Equal(t, "The website redirects temporarily to "+svc.URL+"/page", result[1].Result.Message)
+		assert.Equal(t, LinkResult{Status: true}, result[2].Result)
+	})
+
+	t.Run("Allow Redirect", func(t *testing.T) {
+		//GIVEN
+		allowRedirect := true
+		link := Link{TypeOf: ExternalLink, AbsPath: svc.URL + "/temporary", Config: &LinkConfig{AllowRedirect: &allowRedirect}}
+
+		//WHEN
+		result := check(RedirectConfig{Permanent: FailRedirect, Temporary: FailRedirect}, link)
+
+		//THEN
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Result.Status)
+		assert.Empty(t, result[0].Result.Kind)
+	})
+
+	t.Run("Loop", func(t *testing.T) {
+		//GIVEN
+		link := Link{TypeOf: ExternalLink, AbsPath: svc.URL + "/loop"}
+
+		//WHEN
+		result := check(RedirectConfig{}, link)
+
+		//THEN
+		require.Len(t, result, 1)
+		assert.False(t, result[0].Result.Status)
+		assert.Equal(t, RedirectLoop, result[0].Result.Kind)
+		expected := "The website redirects in a loop: " + svc.URL + "/loop -> " + svc.URL + "/back -> " + svc.URL + "/loop"
+		assert.Equal(t, expected, result[0].Result.Message)
+	})
+
+	t.Run("Max Hops", func(t *testing.T) {
+		//GIVEN
+		link := Link{TypeOf: ExternalLink, AbsPath: svc.URL + "/1"}
+
+		//WHEN
+		limited := check(RedirectConfig{MaxHops: 2}, link)
+		unlimited := check(RedirectConfig{MaxHops: 3}, link)
+
+		//THEN
+		require.Len(t, limited, 1)
+		assert.False(t, limited[0].Result.Status)
+		assert.Equal(t, TooManyRedirects, limited[0].Result.Kind)
+		assert.Equal(t, "The website redirects more than 2 times", limited[0].Result.Message)
+		require.Len(t, unlimited, 1)
+		assert.True(t, unlimited[0].Result.Status)
+		assert.Len(t, unlimited[0].Result.Redirects, 3)
+	})
+}
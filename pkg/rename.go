@@ -0,0 +1,97 @@
+package pkg
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Renames holds files renamed in the git history of the root directory, so links to their previous paths
+// can be changed to the current ones. Paths are absolute.
+type Renames struct {
+	root string
+	// moved is read from the git history once, when the first link to the missing file is looked up,
+	// so runs without such links don't read the whole history
+	once  sync.Once
+	moved map[string]string
+}
+
+// NewRenames returns renames of the git history, which is read when they're needed.
+// Outside of the git repository, there are no renames.
+func NewRenames(root string) *Renames {
+	return &Renames{root: absPath("", root)}
+}
+
+// load reads renames from the git history
+func (r *Renames) load() {
+	r.moved = map[string]string{}
+	output, err := git(r.root, "log", "--format=", "--name-status", "-z", "-M", "--diff-filter=R", "--relative")
+	if err != nil {
+		return
+	}
+
+	fields := strings.Split(output, "\x00")
+	for i := 0; i+2 < len(fields); i++ {
+		status := strings.TrimSpace(fields[i])
+		if !strings.HasPrefix(status, "R") {
+			continue
+		}
+		// the log starts with the latest commit, so the latest rename of the path is kept
+		from, to := absPath(r.root, fields[i+1]), absPath(r.root, fields[i+2])
+		if _, found := r.moved[from]; !found {
+			r.moved[from] = to
+		}
+		i += 2
+	}
+}
+
+// lookup follows renames of the file and returns its current path, if it exists
+func (r *Renames) lookup(filePath string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	r.once.Do(r.load)
+	current, found := absPath("", filePath), false
+	for i := 0; i < len(r.moved); i++ {
+		next, renamed := r.moved[current]
+		if !renamed {
+			break
+		}
+		current, found = next, true
+	}
+	if !found || fileExists(current) != nil {
+		return "", false
+	}
+	return current, true
+}
+
+// suggest returns the link to the current path of the renamed file, written the same way as the link:
+// relative to the directory of the file, or to the base path for links starting with /.
+// The anchor and the query of the link are kept.
+func (r *Renames) suggest(link Link, dirPath string) (string, bool) {
+	target := link.RelPath
+	suffix := ""
+	if i := strings.IndexAny(target, "?#"); i >= 0 {
+		target, suffix = target[:i], target[i:]
+	}
+	filePath := strings.Split(strings.Split(link.AbsPath, "#")[0], "?")[0]
+
+	current, found := r.lookup(filePath)
+	if !found {
+		return "", false
+	}
+
+	from, prefix := absPath("", dirPath), ""
+	if strings.HasPrefix(target, "/") {
+		from, prefix = r.root, "/"
+	}
+	relPath, err := filepath.Rel(from, current)
+	if err != nil {
+		return "", false
+	}
+	relPath = filepath.ToSlash(relPath)
+	if strings.HasPrefix(target, "./") && !strings.HasPrefix(relPath, "../") {
+		relPath = "./" + relPath
+	}
+	return prefix + relPath + suffix, true
+}
@@ -0,0 +1,77 @@
+package pkg
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	TableFormat = "table"
+	JSONFormat  = "json"
+	JUnitFormat = "junit"
+	SARIFFormat = "sarif"
+)
+
+type Reporter interface {
+	Report(w io.Writer, files Files) error
+}
+
+func NewReporter(config *Config) (Reporter, error) {
+	switch config.OutputFormat {
+	case TableFormat, "":
+		return &tableReporter{failOn: config.FailOn}, nil
+	case JSONFormat:
+		return &jsonReporter{config: config}, nil
+	case JUnitFormat:
+		return &junitReporter{failOn: config.FailOn}, nil
+	case SARIFFormat:
+		return &sarifReporter{}, nil
+	}
+	return nil, errors.Errorf("Unknown output format %q", config.OutputFormat)
+}
+
+// WriteReport writes the report of validated files to the output file or to the standard output.
+// With -dry-run, the standard output is only for fixes, so the report goes to the standard error.
+func WriteReport(files Files, config *Config) error {
+	reporter, err := NewReporter(config)
+	if err != nil {
+		return err
+	}
+
+	if config.OutputFile == "" {
+		return reporter.Report(standardOutput(config), files)
+	}
+
+	output, err := os.Create(config.OutputFile)
+	if err != nil {
+		return err
+	}
+	if err := reporter.Report(output, files); err != nil {
+		CloseBody(output)
+		return err
+	}
+	return output.Close()
+}
+
+// standardOutput returns the stream for the report and other output, which isn't written to the output file
+func standardOutput(config *Config) io.Writer {
+	if config.DryRun {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// tableReporter writes links with problems of the failOn severity or more important ones
+type tableReporter struct {
+	failOn Severity
+}
+
+func (r *tableReporter) Report(w io.Writer, files Files) error {
+	if !summaryOfFiles(w, files, r.failOn) {
+		fmt.Fprintln(w, "NO ISSUES :-)")
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package pkg
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// jsonReportVersion changes only when the schema of the report changes in a non backward compatible way
+const jsonReportVersion = 1
+
+type jsonReporter struct {
+	config *Config
+}
+
+type jsonReport struct {
+	Version int         `json:"version"`
+	Status  bool        `json:"status"`
+	Summary jsonSummary `json:"summary"`
+	Config  jsonConfig  `json:"config"`
+	Files   []jsonFile  `json:"files"`
+}
+
+type jsonSummary struct {
+	Files        int `json:"files"`
+	Links        int `json:"links"`
+	SuccessLinks int `json:"successLinks"`
+	FailedLinks  int `json:"failedLinks"`
+	WarningLinks int `json:"warningLinks"`
+	InfoLinks    int `json:"infoLinks"`
+	SkippedLinks int `json:"skippedLinks"`
+}
+
+type jsonConfig struct {
+	BasePath                     string                   `json:"basePath"`
+	Backoff                      string                   `json:"backoff"`
+	Timeout                      int                      `json:"timeout"`
+	RequestRepeats               int                      `json:"requestRepeats"`
+	Concurrency                  int                      `json:"concurrency"`
+	AllowRedirect                bool                     `json:"allowRedirect"`
+	AllowCodeBlocks              bool                     `json:"allowCodeBlocks"`
+	IgnoreExternal               bool                     `json:"ignoreExternal"`
+	IgnoreInternal               bool                     `json:"ignoreInternal"`
+	ExternalLinksToIgnore        []string                 `json:"externalLinksToIgnore"`
+	InternalLinksToIgnore        []string                 `json:"internalLinksToIgnore"`
+	FilesToIgnore                []string                 `json:"filesToIgnore"`
+	FilesToIgnoreInternalLinksIn []string                 `json:"filesToIgnoreInternalLinksIn"`
+	FilesToCheck                 []string                 `json:"filesToCheck"`
+	NoGitignore                  bool                     `json:"noGitignore"`
+	Since                        string                   `json:"since"`
+	AddedLinesOnly               bool                     `json:"addedLinesOnly"`
+	Parser                       string                   `json:"parser"`
+	SlugStyle                    string                   `json:"slugStyle"`
+	RateLimit                    jsonRateLimit            `json:"rateLimit"`
+	Cache                        jsonCache                `json:"cache"`
+	Redirects                    jsonRedirects            `json:"redirects"`
+	Severities                   map[FailureKind]Severity `json:"severities"`
+	FailOn                       Severity                 `json:"failOn"`
+}
+
+type jsonRateLimit struct {
+	RequestsPerSecond float64             `json:"requestsPerSecond"`
+	MaxInFlight       int                 `json:"maxInFlight"`
+	MaxRetryAfter     string              `json:"maxRetryAfter"`
+	Hosts             []jsonHostRateLimit `json:"hosts"`
+}
+
+type jsonHostRateLimit struct {
+	Host              string  `json:"host"`
+	RequestsPerSecond float64 `json:"requestsPerSecond"`
+	MaxInFlight       int     `json:"maxInFlight"`
+}
+
+type jsonCache struct {
+	Disabled   bool   `json:"disabled"`
+	File       string `json:"file"`
+	SuccessTTL string `json:"successTTL"`
+	FailureTTL string `json:"failureTTL"`
+}
+
+type jsonRedirects struct {
+	MaxHops   int            `json:"maxHops"`
+	Permanent RedirectPolicy `json:"permanent"`
+	Temporary RedirectPolicy `json:"temporary"`
+}
+
+type jsonFile struct {
+	Path   string     `json:"path"`
+	Status bool       `json:"status"`
+	Links  []jsonLink `json:"links"`
+}
+
+type jsonLink struct {
+	Path   string         `json:"path"`
+	Type   LinkType       `json:"type"`
+	Line   int            `json:"line"`
+	Column int            `json:"column"`
+	Text   string         `json:"text"`
+	Result jsonLinkResult `json:"result"`
+}
+
+type jsonLinkResult struct {
+	Status    bool        `json:"status"`
+	Message   string      `json:"message"`
+	Kind      FailureKind `json:"kind,omitempty"`
+	Skipped   bool        `json:"skipped,omitempty"`
+	Fix       string      `json:"fix,omitempty"`
+	Severity  Severity    `json:"severity,omitempty"`
+	Redirects []Redirect  `json:"redirects,omitempty"`
+}
+
+func (r *jsonReporter) Report(w io.Writer, files Files) error {
+	report := jsonReport{
+		Version: jsonReportVersion,
+		Status:  true,
+		Config:  newJSONConfig(r.config),
+		Files:   []jsonFile{},
+	}
+
+	for _, file := range files {
+		jsonFile := jsonFile{
+			Path:   file.RelPath,
+			Status: true,
+			Links:  []jsonLink{},
+		}
+		for _, link := range file.Links {
+			jsonFile.Links = append(jsonFile.Links, jsonLink{
+				Path:   linkPath(link),
+				Type:   link.TypeOf,
+				Line:   link.Line,
+				Column: link.Column,
+				Text:   link.Text,
+				Result: jsonLinkResult{
+					Status:    link.Result.Status,
+					Message:   link.Result.Message,
+					Kind:      link.Result.Kind,
+					Skipped:   link.Result.Skipped,
+					Fix:       link.Result.Fix,
+					Severity:  link.Result.severity(),
+					Redirects: link.Result.Redirects,
+				},
+			})
+			if link.Result.fails(r.failOn()) {
+				jsonFile.Status = false
+				report.Status = false
+			}
+			switch {
+			case link.Result.Skipped:
+				report.Summary.SkippedLinks++
+			case !link.Result.Status:
+				report.Summary.FailedLinks++
+			case link.Result.Severity == WarningSeverity:
+				report.Summary.WarningLinks++
+			case link.Result.Severity == InfoSeverity:
+				report.Summary.InfoLinks++
+			default:
+				report.Summary.SuccessLinks++
+			}
+		}
+		report.Files = append(report.Files, jsonFile)
+		report.Summary.Links += len(file.Links)
+	}
+	report.Summary.Files = len(files)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// failOn returns the lowest severity of problems which fail the check
+func (r *jsonReporter) failOn() Severity {
+	if r.config == nil {
+		return ErrorSeverity
+	}
+	return r.config.FailOn
+}
+
+func newJSONConfig(config *Config) jsonConfig {
+	if config == nil {
+		return jsonConfig{}
+	}
+
+	severities := map[FailureKind]Severity{}
+	for kind, severity := range config.Severities {
+		severities[kind] = severity
+	}
+	return jsonConfig{
+		BasePath:                     config.BasePath,
+		Backoff:                      config.Backoff.String(),
+		Timeout:                      config.Timeout,
+		RequestRepeats:               config.RequestRepeats,
+		Concurrency:                  config.Concurrency,
+		AllowRedirect:                config.AllowRedirect,
+		AllowCodeBlocks:              config.AllowCodeBlocks,
+		IgnoreExternal:               config.IgnoreExternal,
+		IgnoreInternal:               config.IgnoreInternal,
+		ExternalLinksToIgnore:        sorted(config.ExternalLinksToIgnore),
+		InternalLinksToIgnore:        sorted(config.InternalLinksToIgnore),
+		FilesToIgnore:                sorted(config.FilesToIgnore),
+		FilesToIgnoreInternalLinksIn: sorted(config.FilesToIgnoreInternalLinksIn),
+		// patterns of files to check are kept in their order, as the latter ones may exclude files
+		FilesToCheck:   append([]string{}, config.FilesToCheck...),
+		NoGitignore:    config.NoGitignore,
+		Since:          config.Since,
+		AddedLinesOnly: config.AddedLinesOnly,
+		Parser:         config.Parser,
+		SlugStyle:      config.SlugStyle,
+		RateLimit:      newJSONRateLimit(config.RateLimit),
+		Cache: jsonCache{
+			Disabled:   config.Cache.Disabled,
+			File:       config.Cache.File,
+			SuccessTTL: config.Cache.SuccessTTL.String(),
+			FailureTTL: config.Cache.FailureTTL.String(),
+		},
+		Redirects: jsonRedirects{
+			MaxHops:   config.Redirects.MaxHops,
+			Permanent: config.Redirects.Permanent,
+			Temporary: config.Redirects.Temporary,
+		},
+		Severities: severities,
+		FailOn:     config.FailOn,
+	}
+}
+
+func newJSONRateLimit(config RateLimitConfig) jsonRateLimit {
+	rateLimit := jsonRateLimit{
+		RequestsPerSecond: config.RequestsPerSecond,
+		MaxInFlight:       config.MaxInFlight,
+		MaxRetryAfter:     config.MaxRetryAfter.String(),
+		Hosts:             []jsonHostRateLimit{},
+	}
+	for _, host := range config.Hosts {
+		rateLimit.Hosts = append(rateLimit.Hosts, jsonHostRateLimit{
+			Host:              host.Host,
+			RequestsPerSecond: host.RequestsPerSecond,
+			MaxInFlight:       host.MaxInFlight,
+		})
+	}
+	return rateLimit
+}
+
+// sorted returns the sorted copy, so the report doesn't depend on the order of unique
+func sorted(elements []string) []string {
+	result := append([]string{}, elements...)
+	sort.Strings(result)
+	return result
+}
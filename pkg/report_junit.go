@@ -0,0 +1,99 @@
+package pkg
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+)
+
+// junitReporter reports links with problems of the failOn severity or more important ones as failures
+type junitReporter struct {
+	failOn Severity
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr,omitempty"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr,omitempty"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+// Report maps every file to the test suite and every link to the test case
+func (r *junitReporter) Report(w io.Writer, files Files) error {
+	report := junitTestSuites{Name: "milv"}
+
+	for _, file := range files {
+		suite := junitTestSuite{Name: file.RelPath}
+		for _, link := range file.Links {
+			testCase := junitTestCase{
+				Name:      junitTestCaseName(link),
+				ClassName: file.RelPath,
+			}
+			if link.Result.Skipped {
+				testCase.Skipped = &junitSkipped{Message: link.Result.Message}
+				suite.Skipped++
+			} else if link.Result.fails(r.failOn) {
+				testCase.Failure = &junitFailure{
+					Message: link.Result.Message,
+					Type:    string(link.TypeOf),
+					Text:    fmt.Sprintf("%s:%s: %s: %s", file.RelPath, linkPosition(link), link.Text, link.Result.Message),
+				}
+				suite.Failures++
+			}
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+		suite.Tests = len(suite.TestCases)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// junitTestCaseName returns the link with its position, so test cases of the same link used many times
+// in the file have different names
+func junitTestCaseName(link Link) string {
+	if position := linkPosition(link); position != "" {
+		return fmt.Sprintf("%s (%s)", linkPath(link), position)
+	}
+	return linkPath(link)
+}
@@ -0,0 +1,189 @@
+package pkg
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+// sarifRules describes every failure kind, the rule ID is the failure kind
+var sarifRules = []sarifRule{
+	{ID: string(MissingFile), ShortDescription: sarifMessage{Text: "The linked file doesn't exist"}},
+	{ID: string(MissingHeader), ShortDescription: sarifMessage{Text: "The linked header doesn't exist in the file"}},
+	{ID: string(HTTPStatus), ShortDescription: sarifMessage{Text: "The website responds with an error status code"}},
+	{ID: string(MissingAnchor), ShortDescription: sarifMessage{Text: "The linked anchor doesn't exist on the website"}},
+	{ID: string(TooManyRequests), ShortDescription: sarifMessage{Text: "The website responds with the 429 status code (Too many requests)"}},
+	{ID: string(RequestError), ShortDescription: sarifMessage{Text: "The request to the website fails"}},
+	{ID: string(RequestTimeout), ShortDescription: sarifMessage{Text: "The website doesn't respond before the timeout"}},
+	{ID: string(InvalidURL), ShortDescription: sarifMessage{Text: "The link isn't a valid URL"}},
+	{ID: string(UndefinedReference), ShortDescription: sarifMessage{Text: "The reference link has no link definition in the file"}},
+	{ID: string(UnusedDefinition), ShortDescription: sarifMessage{Text: "No link in the file refers to the link definition"}},
+	{ID: string(PermanentRedirect), ShortDescription: sarifMessage{Text: "The website redirects permanently (301 or 308)"}},
+	{ID: string(TemporaryRedirect), ShortDescription: sarifMessage{Text: "The website redirects temporarily (302, 303 or 307)"}},
+	{ID: string(RedirectLoop), ShortDescription: sarifMessage{Text: "The website redirects in a loop"}},
+	{ID: string(TooManyRedirects), ShortDescription: sarifMessage{Text: "The website redirects more times than allowed"}},
+}
+
+type sarifReporter struct{}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int           `json:"startLine"`
+	StartColumn int           `json:"startColumn,omitempty"`
+	EndLine     int           `json:"endLine,omitempty"`
+	EndColumn   int           `json:"endColumn,omitempty"`
+	Snippet     *sarifMessage `json:"snippet,omitempty"`
+}
+
+// Report converts broken links to SARIF results, so they can be shown as code scanning alerts
+func (*sarifReporter) Report(w io.Writer, files Files) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "milv",
+			InformationURI: "https://github.com/kyma-incubator/milv",
+			Rules:          []sarifRule{},
+		}},
+		// columns of links are counted in characters, not in bytes
+		ColumnKind: "unicodeCodePoints",
+		Results:    []sarifResult{},
+	}
+
+	ruleIndexes := map[string]int{}
+	for i, rule := range sarifRules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+		ruleIndexes[rule.ID] = i
+	}
+
+	for _, file := range files {
+		stats := file.Stats
+		if stats == nil {
+			stats = NewFileStats(file)
+		}
+
+		// warnings and information are valid links, which are reported with lower levels
+		links := append([]Link{}, stats.FailedLinks.Links...)
+		links = append(links, stats.WarningLinks.Links...)
+		links = append(links, stats.InfoLinks.Links...)
+
+		for _, link := range links {
+			ruleID := string(link.Result.Kind)
+			ruleIndex, found := ruleIndexes[ruleID]
+			if !found {
+				ruleIndex = len(run.Tool.Driver.Rules)
+				ruleIndexes[ruleID] = ruleIndex
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+					ID:               ruleID,
+					ShortDescription: sarifMessage{Text: "The link is broken"},
+				})
+			}
+
+			location := sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: sarifURI(file.RelPath)},
+			}
+			if link.Line > 0 {
+				location.Region = &sarifRegion{StartLine: link.Line, StartColumn: link.Column}
+				if link.Text != "" {
+					location.Region.EndLine, location.Region.EndColumn = sarifEnd(link)
+					location.Region.Snippet = &sarifMessage{Text: link.Text}
+				}
+			}
+
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    ruleID,
+				RuleIndex: ruleIndex,
+				Level:     sarifLevel(link.Result.severity()),
+				Message:   sarifMessage{Text: linkPath(link) + ": " + link.Result.Message},
+				Locations: []sarifLocation{{PhysicalLocation: location}},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifLevel returns the level of the result with the severity, SARIF calls information notes
+func sarifLevel(severity Severity) string {
+	if severity == InfoSeverity {
+		return "note"
+	}
+	return string(severity)
+}
+
+// sarifURI returns the path without the leading ./, as expected by the code scanning
+func sarifURI(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
+
+// sarifEnd returns the line and the column after the end of the link, the text of the link can wrap onto other lines
+func sarifEnd(link Link) (int, int) {
+	lines := strings.Count(link.Text, "\n")
+	if lines == 0 {
+		return 0, link.Column + utf8.RuneCountInString(link.Text)
+	}
+	lastLine := link.Text[strings.LastIndex(link.Text, "\n")+1:]
+	return link.Line + lines, utf8.RuneCountInString(lastLine) + 1
+}
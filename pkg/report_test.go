@@ -0,0 +1,300 @@
+package pkg
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	files := Files{
+		&File{
+			RelPath: "./README.md",
+			Status:  false,
+			Links: Links{
+				{
+					AbsPath: "https://github.com",
+					TypeOf:  ExternalLink,
+					Line:    3,
+					Column:  1,
+					Text:    "https://github.com",
+					Result:  LinkResult{Status: true},
+				},
+				{
+					RelPath: "#header",
+					TypeOf:  HashInternalLink,
+					Line:    7,
+					Column:  12,
+					Text:    "[Header](#header)",
+					Result:  LinkResult{Status: false, Message: "The specified header doesn't exist in this file", Kind: MissingHeader},
+				},
+			},
+		},
+		&File{
+			RelPath: "./docs/empty.md",
+			Status:  true,
+		},
+	}
+	for _, file := range files {
+		file.ExtractStats()
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		//GIVEN
+		config := &Config{
+			OutputFormat:          JSONFormat,
+			Backoff:               time.Second,
+			Concurrency:           2,
+			ExternalLinksToIgnore: []string{"localhost", "abc.com"},
+			FilesToCheck:          []string{"**/*.md", "!vendor/**"},
+			Parser:                CommonMarkParser,
+			SlugStyle:             GitHubSlugStyle,
+			RateLimit:             RateLimitConfig{MaxRetryAfter: time.Minute, Hosts: []HostRateLimit{{Host: "github.com", MaxInFlight: 1}}},
+			Cache:                 CacheConfig{File: ".milv-cache.json", SuccessTTL: 24 * time.Hour},
+			Redirects:             RedirectConfig{MaxHops: DefaultMaxRedirects, Permanent: WarnRedirect, Temporary: PassRedirect},
+			Severities:            Severities{RequestTimeout: WarningSeverity},
+			FailOn:                ErrorSeverity,
+		}
+		reporter, err := NewReporter(config)
+		require.NoError(t, err)
+		buffer := &bytes.Buffer{}
+
+		//WHEN
+		err = reporter.Report(buffer, files)
+
+		//THEN
+		require.NoError(t, err)
+		expected := `{
+  "version": 1,
+  "status": false,
+  "summary": {"files": 2, "links": 2, "successLinks": 1, "failedLinks": 1, "warningLinks": 0, "infoLinks": 0, "skippedLinks": 0},
+  "config": {
+    "basePath": "",
+    "backoff": "1s",
+    "timeout": 0,
+    "requestRepeats": 0,
+    "concurrency": 2,
+    "allowRedirect": false,
+    "allowCodeBlocks": false,
+    "ignoreExternal": false,
+    "ignoreInternal": false,
+    "externalLinksToIgnore": ["abc.com", "localhost"],
+    "internalLinksToIgnore": [],
+    "filesToIgnore": [],
+    "filesToIgnoreInternalLinksIn": [],
+    "filesToCheck": ["**/*.md", "!vendor/**"],
+    "noGitignore": false,
+    "since": "",
+    "addedLinesOnly": false,
+    "parser": "commonmark",
+    "slugStyle": "github",
+    "rateLimit": {
+      "requestsPerSecond": 0,
+      "maxInFlight": 0,
+      "maxRetryAfter": "1m0s",
+      "hosts": [{"host": "github.com", "requestsPerSecond": 0, "maxInFlight": 1}]
+    },
+    "cache": {"disabled": false, "file": ".milv-cache.json", "successTTL": "24h0m0s", "failureTTL": "0s"},
+    "redirects": {"maxHops": 10, "permanent": "warn", "temporary": "pass"},
+    "severities": {"RequestTimeout": "warning"},
+    "failOn": "error"
+  },
+  "files": [
+    {
+      "path": "./README.md",
+      "status": false,
+      "links": [
+        {"path": "https://github.com", "type": "ExternalLink", "line": 3, "column": 1, "text": "https://github.com", "result": {"status": true, "message": ""}},
+        {"path": "#header", "type": "HashInternalLink", "line": 7, "column": 12, "text": "[Header](#header)", "result": {"status": false, "message": "The specified header doesn't exist in this file", "kind": "MissingHeader", "severity": "error"}}
+      ]
+    },
+    {"path": "./docs/empty.md", "status": true, "links": []}
+  ]
+}`
+		assert.JSONEq(t, expected, buffer.String())
+	})
+
+	t.Run("JUnit", func(t *testing.T) {
+		//GIVEN
+		reporter, err := NewReporter(&Config{OutputFormat: JUnitFormat})
+		require.NoError(t, err)
+		buffer := &bytes.Buffer{}
+
+		//WHEN
+		err = reporter.Report(buffer, files)
+
+		//THEN
+		require.NoError(t, err)
+		expected := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="milv" tests="2" failures="1">
+  <testsuite name="./README.md" tests="2" failures="1">
+    <testcase name="https://github.com (3:1)" classname="./README.md"></testcase>
+    <testcase name="#header (7:12)" classname="./README.md">
+      <failure message="The specified header doesn&#39;t exist in this file" type="HashInternalLink">./README.md:7:12: [Header](#header): The specified header doesn&#39;t exist in this file</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="./docs/empty.md" tests="0" failures="0"></testsuite>
+</testsuites>
+`
+		assert.Equal(t, expected, buffer.String())
+	})
+
+	t.Run("JUnit with the same link", func(t *testing.T) {
+		//GIVEN
+		reporter, err := NewReporter(&Config{OutputFormat: JUnitFormat})
+		require.NoError(t, err)
+		buffer := &bytes.Buffer{}
+		link := Link{AbsPath: "https://github.com", TypeOf: ExternalLink, Result: LinkResult{Status: true}}
+		first, second, unknown := link, link, link
+		first.Line, first.Column = 3, 1
+		second.Line, second.Column = 9, 5
+		sameLinks := Files{&File{RelPath: "./README.md", Links: Links{first, second, unknown}}}
+
+		//WHEN
+		err = reporter.Report(buffer, sameLinks)
+
+		//THEN
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), `<testcase name="https://github.com (3:1)" classname="./README.md">`)
+		assert.Contains(t, buffer.String(), `<testcase name="https://github.com (9:5)" classname="./README.md">`)
+		assert.Contains(t, buffer.String(), `<testcase name="https://github.com" classname="./README.md">`)
+	})
+
+	t.Run("SARIF", func(t *testing.T) {
+		//GIVEN
+		reporter, err := NewReporter(&Config{OutputFormat: SARIFFormat})
+		require.NoError(t, err)
+		buffer := &bytes.Buffer{}
+
+		//WHEN
+		err = reporter.Report(buffer, files)
+
+		//THEN
+		require.NoError(t, err)
+		report := sarifLog{}
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &report))
+		assert.Equal(t, "2.1.0", report.Version)
+		require.Len(t, report.Runs, 1)
+
+		run := report.Runs[0]
+		assert.Equal(t, "milv", run.Tool.Driver.Name)
+		assert.Len(t, run.Tool.Driver.Rules, len(sarifRules))
+		require.Len(t, run.Results, 1)
+
+		result := run.Results[0]
+		assert.Equal(t, "MissingHeader", result.RuleID)
+		assert.Equal(t, string(MissingHeader), run.Tool.Driver.Rules[result.RuleIndex].ID)
+		assert.Equal(t, "error", result.Level)
+		assert.Equal(t, "#header: The specified header doesn't exist in this file", result.Message.Text)
+		assert.Equal(t, []sarifLocation{{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: "README.md"},
+				Region: &sarifRegion{
+					StartLine:   7,
+					StartColumn: 12,
+					EndColumn:   29,
+					Snippet:     &sarifMessage{Text: "[Header](#header)"},
+				},
+			},
+		}}, result.Locations)
+	})
+
+	t.Run("SARIF with the wrapped link", func(t *testing.T) {
+		//GIVEN
+		reporter, err := NewReporter(&Config{OutputFormat: SARIFFormat})
+		require.NoError(t, err)
+		buffer := &bytes.Buffer{}
+		wrapped := Files{&File{RelPath: "./README.md", Links: Links{{
+			RelPath: "#header",
+			TypeOf:  HashInternalLink,
+			Line:    4,
+			Column:  20,
+			Text:    "[Wrapped\nheader](#header)",
+			Result:  LinkResult{Message: "The specified header doesn't exist in this file", Kind: MissingHeader},
+		}}}}
+		wrapped[0].ExtractStats()
+
+		//WHEN
+		err = reporter.Report(buffer, wrapped)
+
+		//THEN
+		require.NoError(t, err)
+		report := sarifLog{}
+		require.NoError(t, json.Unmarshal(buffer.Bytes(), &report))
+		require.Len(t, report.Runs[0].Results, 1)
+		assert.Equal(t, &sarifRegion{
+			StartLine:   4,
+			StartColumn: 20,
+			EndLine:     5,
+			EndColumn:   17,
+			Snippet:     &sarifMessage{Text: "[Wrapped\nheader](#header)"},
+		}, report.Runs[0].Results[0].Locations[0].PhysicalLocation.Region)
+	})
+
+	t.Run("Table", func(t *testing.T) {
+		//GIVEN
+		reporter, err := NewReporter(&Config{})
+		require.NoError(t, err)
+		buffer := &bytes.Buffer{}
+
+		//WHEN
+		err = reporter.Report(buffer, files)
+
+		//THEN
+		require.NoError(t, err)
+		assert.Contains(t, buffer.String(), "SUMMARY")
+		assert.Contains(t, buffer.String(), "#header")
+		assert.Contains(t, buffer.String(), "7:12")
+		assert.NotContains(t, buffer.String(), "NO ISSUES")
+	})
+
+	t.Run("Table without issues", func(t *testing.T) {
+		//GIVEN
+		reporter, err := NewReporter(&Config{OutputFormat: TableFormat})
+		require.NoError(t, err)
+		buffer := &bytes.Buffer{}
+
+		//WHEN
+		err = reporter.Report(buffer, files[1:])
+
+		//THEN
+		require.NoError(t, err)
+		assert.Equal(t, "NO ISSUES :-)\n", buffer.String())
+	})
+
+	t.Run("Dry run", func(t *testing.T) {
+		assert.Equal(t, os.Stdout, standardOutput(&Config{Fix: true}))
+		assert.Equal(t, os.Stderr, standardOutput(&Config{Fix: true, DryRun: true}))
+	})
+
+	t.Run("Output file", func(t *testing.T) {
+		//GIVEN
+		config := &Config{
+			OutputFormat: JSONFormat,
+			OutputFile:   filepath.Join(t.TempDir(), "report.json"),
+		}
+
+		//WHEN
+		err := WriteReport(files, config)
+
+		//THEN
+		require.NoError(t, err)
+		content, err := ioutil.ReadFile(config.OutputFile)
+		require.NoError(t, err)
+		report := jsonReport{}
+		require.NoError(t, json.Unmarshal(content, &report))
+		assert.Len(t, report.Files, 2)
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		_, err := NewReporter(&Config{OutputFormat: "xml"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+package pkg
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Severity tells how important the problem of the link is. Only errors make the link broken,
+// links with warnings and information are valid.
+type Severity string
+
+const (
+	ErrorSeverity   Severity = "error"
+	WarningSeverity Severity = "warning"
+	InfoSeverity    Severity = "info"
+)
+
+// severityLevels orders severities, the higher level is more important
+var severityLevels = map[Severity]int{
+	InfoSeverity:    1,
+	WarningSeverity: 2,
+	ErrorSeverity:   3,
+}
+
+// Severities maps kinds of problems to their severities. Problems of other kinds are errors,
+// except redirects reported with the warn policy, which are warnings.
+type Severities map[FailureKind]Severity
+
+func validateSeverities(severities Severities, failOn Severity) error {
+	for kind, severity := range severities {
+		if _, found := severityLevels[severity]; !found {
+			return errors.Errorf("Unknown severity %q of %s", severity, kind)
+		}
+	}
+	if _, found := severityLevels[failOn]; !found {
+		return errors.Errorf("Unknown severity %q to fail on", failOn)
+	}
+	return nil
+}
+
+// apply sets the severity of the problem of the link, if its kind is mapped, and the status according to it
+func (s Severities) apply(result LinkResult) LinkResult {
+	if result.Kind == "" || result.Skipped {
+		return result
+	}
+
+	if severity, found := s[result.Kind]; found {
+		result.Severity = severity
+		result.Status = severity != ErrorSeverity
+	}
+	return result
+}
+
+// severity returns the severity of the problem of the link: errors for broken links, the severity of the result
+// for valid links with problems, and an empty severity for valid and skipped links
+func (r LinkResult) severity() Severity {
+	if r.Skipped {
+		return ""
+	}
+	if !r.Status {
+		return ErrorSeverity
+	}
+	return r.Severity
+}
+
+// fails checks if the problem of the link fails the check, when problems of the failOn severity
+// and more important ones fail it
+func (r LinkResult) fails(failOn Severity) bool {
+	if failOn == "" {
+		failOn = ErrorSeverity
+	}
+	return r.severity().atLeast(failOn)
+}
+
+// atLeast checks if the severity is the same or more important than the other one
+func (s Severity) atLeast(other Severity) bool {
+	return s != "" && severityLevels[s] >= severityLevels[other]
+}
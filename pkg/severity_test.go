@@ -0,0 +1,174 @@
+package pkg
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kyma-incubator/milv/cli"
+)
+
+func TestSeverities(t *testing.T) {
+	t.Run("Severities", func(t *testing.T) {
+		//GIVEN
+		v := NewValidator(http.Client{}, &waitMock{})
+		v.pool = newWorkerPool(1)
+		v.severities = Severities{MissingFile: WarningSeverity, UnusedDefinition: InfoSeverity, MissingHeader: ErrorSeverity}
+		links := []Link{
+			{TypeOf: InternalLink, RelPath: "test-markdowns/missing.md"},
+			{TypeOf: ReferenceLink, RelPath: "unused", Result: LinkResult{Message: "The link definition isn't used", Kind: UnusedDefinition}},
+			{TypeOf: HashInternalLink, RelPath: "#missing"},
+			{TypeOf: HashInternalLink, RelPath: "#header"},
+			{TypeOf: InternalLink, RelPath: "test-markdowns/missing.md", Result: LinkResult{Status: true, Skipped: true}},
+		}
+
+		//WHEN
+		result := v.Links(links, Headers{{Text: "Header"}})
+
+		//THEN
+		require.Len(t, result, 5)
+		assert.True(t, result[0].Result.Status)
+		assert.Equal(t, WarningSeverity, result[0].Result.Severity)
+		assert.Equal(t, MissingFile, result[0].Result.Kind)
+		assert.True(t, result[1].Result.Status)
+		assert.Equal(t, InfoSeverity, result[1].Result.Severity)
+		assert.False(t, result[2].Result.Status)
+		assert.Equal(t, ErrorSeverity, result[2].Result.Severity)
+		assert.Equal(t, LinkResult{Status: true}, result[3].Result)
+		assert.Equal(t, LinkResult{Status: true, Skipped: true}, result[4].Result)
+	})
+
+	t.Run("Request Timeout", func(t *testing.T) {
+		//GIVEN
+		svc := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			select {
+			case <-request.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+		defer svc.Close()
+
+		v := NewValidator(http.Client{}, &waitMock{})
+		v.pool = newWorkerPool(1)
+		v.severities = Severities{RequestTimeout: WarningSeverity}
+		timeout := 1
+		link := Link{TypeOf: ExternalLink, AbsPath: svc.URL, Config: &LinkConfig{Timeout: &timeout}}
+
+		//WHEN
+		result := v.Links([]Link{link})
+
+		//THEN
+		require.Len(t, result, 1)
+		assert.True(t, result[0].Result.Status)
+		assert.Equal(t, RequestTimeout, result[0].Result.Kind)
+		assert.Equal(t, WarningSeverity, result[0].Result.Severity)
+	})
+
+	t.Run("Stats And Fail On", func(t *testing.T) {
+		//GIVEN
+		file := &File{Links: Links{
+			{RelPath: "a.md", Result: LinkResult{Status: true}},
+			{RelPath: "b.md", Result: LinkResult{Status: true, Kind: PermanentRedirect, Severity: WarningSeverity}},
+			{RelPath: "c.md", Result: LinkResult{Status: true, Kind: UnusedDefinition, Severity: InfoSeverity}},
+			{RelPath: "d.md", Result: LinkResult{Status: true, Skipped: true, Kind: MissingFile}},
+		}}
+		broken := &File{Links: Links{{RelPath: "e.md", Result: LinkResult{Kind: MissingFile}}}}
+
+		//WHEN
+		stats := NewFileStats(file)
+
+		//THEN
+		assert.Equal(t, 1, stats.SuccessLinks.Count)
+		assert.Equal(t, 1, stats.WarningLinks.Count)
+		assert.Equal(t, 1, stats.InfoLinks.Count)
+		assert.Equal(t, 1, stats.SkippedLinks.Count)
+		assert.Equal(t, 0, stats.FailedLinks.Count)
+		assert.Equal(t, "warning", linkStatus(file.Links[1]))
+
+		assert.False(t, Files{file}.Failed())
+		assert.False(t, Files{file}.FailedOn(ErrorSeverity))
+		assert.True(t, Files{file}.FailedOn(WarningSeverity))
+		assert.True(t, Files{file}.FailedOn(InfoSeverity))
+		assert.True(t, Files{file, broken}.Failed())
+	})
+}
+
+func TestFailOn(t *testing.T) {
+	svc := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/moved" {
+			http.Redirect(writer, request, "/page", http.StatusMovedPermanently)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer svc.Close()
+
+	root := t.TempDir()
+	configFile := filepath.Join(root, "milv.config.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("redirects:\n  permanent: warn\n"), 0644))
+	filePath := filepath.Join(root, "README.md")
+	require.NoError(t, os.WriteFile(filePath, []byte("# README\n\n[Moved]("+svc.URL+"/moved)\n"), 0644))
+
+	run := func(t *testing.T, failOn, outputFormat string) (Files, string) {
+		commands := cli.Commands{
+			ConfigFile:   configFile,
+			BasePath:     root,
+			FailOn:       failOn,
+			OutputFormat: outputFormat,
+			OutputFile:   filepath.Join(t.TempDir(), "report"),
+			FlagsSet:     map[string]bool{"fail-on": true, "output-format": true, "output-file": true},
+		}
+		config, err := NewConfig(commands)
+		require.NoError(t, err)
+		files, err := NewFiles([]string{filePath}, config)
+		require.NoError(t, err)
+		files.Run(false)
+		require.NoError(t, WriteReport(files, config))
+		report, err := os.ReadFile(config.OutputFile)
+		require.NoError(t, err)
+		return files, string(report)
+	}
+
+	t.Run("Warning Fails", func(t *testing.T) {
+		//WHEN
+		files, table := run(t, "warning", TableFormat)
+		_, jsonOutput := run(t, "warning", JSONFormat)
+		_, junitReport := run(t, "warning", JUnitFormat)
+
+		//THEN
+		assert.True(t, files.FailedOn(WarningSeverity))
+		assert.Contains(t, table, svc.URL+"/moved")
+		assert.Contains(t, table, "| warning  |")
+		assert.NotContains(t, table, "NO ISSUES")
+		report := jsonReport{}
+		require.NoError(t, json.Unmarshal([]byte(jsonOutput), &report))
+		assert.False(t, report.Status)
+		assert.False(t, report.Files[0].Status)
+		assert.Equal(t, 1, report.Summary.WarningLinks)
+		assert.Contains(t, junitReport, `failures="1"`)
+		assert.Contains(t, junitReport, "<failure message=\"The website redirects permanently")
+	})
+
+	t.Run("Warning Passes", func(t *testing.T) {
+		//WHEN
+		files, table := run(t, "error", TableFormat)
+		_, jsonOutput := run(t, "error", JSONFormat)
+		_, junitReport := run(t, "error", JUnitFormat)
+
+		//THEN
+		assert.False(t, files.FailedOn(ErrorSeverity))
+		assert.Equal(t, "NO ISSUES :-)\n", table)
+		report := jsonReport{}
+		require.NoError(t, json.Unmarshal([]byte(jsonOutput), &report))
+		assert.True(t, report.Status)
+		assert.Equal(t, 1, report.Summary.WarningLinks)
+		assert.Contains(t, junitReport, `failures="0"`)
+	})
+}
@@ -0,0 +1,148 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	GitHubSlugStyle     = "github"
+	GitLabSlugStyle     = "gitlab"
+	HugoSlugStyle       = "hugo"
+	DocusaurusSlugStyle = "docusaurus"
+)
+
+// Slugger generates anchors of headings the way the markdown renderer does
+type Slugger interface {
+	// Slug returns the anchor of the heading, without the suffix added to duplicated anchors
+	Slug(heading string) string
+	// ExplicitIDs tells if the heading can set its anchor with {#custom-id}
+	ExplicitIDs() bool
+}
+
+func NewSlugger(style string) (Slugger, error) {
+	switch style {
+	case GitHubSlugStyle, "":
+		return githubSlugger{}, nil
+	case GitLabSlugStyle:
+		return gitlabSlugger{}, nil
+	case HugoSlugStyle:
+		return hugoSlugger{}, nil
+	case DocusaurusSlugStyle:
+		return docusaurusSlugger{}, nil
+	}
+	return nil, errors.Errorf("Unknown slug style %q", style)
+}
+
+// slugs returns anchors of headings in the order they appear in the document.
+// The duplicated anchor gets the number suffix, e.g. setup, setup-1, setup-2.
+func slugs(slugger Slugger, headings []string) []string {
+	occurrences := map[string]int{}
+	var result []string
+	for _, heading := range headings {
+		slug := slugger.Slug(heading)
+		original := slug
+		for {
+			if _, found := occurrences[slug]; !found {
+				break
+			}
+			occurrences[original]++
+			slug = fmt.Sprintf("%s-%d", original, occurrences[original])
+		}
+		occurrences[slug] = 0
+		result = append(result, slug)
+	}
+	return result
+}
+
+// headerAnchors returns anchors of the headers: IDs of HTML elements and sections, explicit IDs of headings
+// if the slugger supports them, and slugs of other headings
+func headerAnchors(slugger Slugger, headers Headers) []string {
+	var anchors, headings []string
+	for _, header := range headers {
+		fixed := header.Origin == HTMLHeader || header.Origin == AnchorHeader || header.Origin == SectionHeader
+		if header.ID != "" && (fixed || header.Text == "" || slugger.ExplicitIDs()) {
+			anchors = append(anchors, header.ID)
+		} else if header.Text != "" {
+			headings = append(headings, header.Text)
+		}
+	}
+	return append(anchors, slugs(slugger, headings)...)
+}
+
+// githubSlugger reproduces github-slugger: letters, numbers, marks, underscores, hyphens and spaces are kept,
+// and every space becomes the hyphen
+type githubSlugger struct{}
+
+func (githubSlugger) Slug(heading string) string {
+	var slug strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			slug.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			slug.WriteRune(r)
+		}
+	}
+	return slug.String()
+}
+
+func (githubSlugger) ExplicitIDs() bool {
+	return false
+}
+
+// docusaurusSlugger uses the same library as GitHub, but headings can have explicit IDs
+type docusaurusSlugger struct {
+	githubSlugger
+}
+
+func (docusaurusSlugger) ExplicitIDs() bool {
+	return true
+}
+
+// gitlabSlugger keeps word characters, hyphens and spaces, replaces spaces with hyphens
+// and squeezes repeated hyphens
+type gitlabSlugger struct{}
+
+func (gitlabSlugger) Slug(heading string) string {
+	var slug strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case r == ' ' || r == '-':
+			if !strings.HasSuffix(slug.String(), "-") {
+				slug.WriteRune('-')
+			}
+		case r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r):
+			slug.WriteRune(r)
+		}
+	}
+	return slug.String()
+}
+
+func (gitlabSlugger) ExplicitIDs() bool {
+	return false
+}
+
+// hugoSlugger reproduces the default auto heading ID of Hugo: letters, digits and underscores are kept,
+// and spaces and hyphens become hyphens
+type hugoSlugger struct{}
+
+func (hugoSlugger) Slug(heading string) string {
+	var slug strings.Builder
+	for _, r := range strings.TrimSpace(heading) {
+		switch {
+		case r == ' ' || r == '-':
+			slug.WriteRune('-')
+		case r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			slug.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return slug.String()
+}
+
+func (hugoSlugger) ExplicitIDs() bool {
+	return true
+}
@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlug(t *testing.T) {
+	headings := []string{
+		"Setup",
+		"Setup",
+		"Setup-1",
+		"Setup",
+		"What's new in v1.2?",
+		"Übersicht & Café",
+		"🚀 Getting started",
+		"snake_case  and  spaces",
+		"--Dashes--",
+	}
+
+	t.Run("GitHub", func(t *testing.T) {
+		//GIVEN
+		slugger, err := NewSlugger(GitHubSlugStyle)
+		require.NoError(t, err)
+
+		//WHEN
+		result := slugs(slugger, headings)
+
+		//THEN
+		assert.Equal(t, []string{
+			"setup",
+			"setup-1",
+			"setup-1-1",
+			"setup-2",
+			"whats-new-in-v12",
+			"übersicht--café",
+			"-getting-started",
+			"snake_case--and--spaces",
+			"--dashes--",
+		}, result)
+	})
+
+	t.Run("GitLab", func(t *testing.T) {
+		//GIVEN
+		slugger, err := NewSlugger(GitLabSlugStyle)
+		require.NoError(t, err)
+
+		//WHEN
+		result := slugs(slugger, headings)
+
+		//THEN
+		assert.Equal(t, []string{
+			"setup",
+			"setup-1",
+			"setup-1-1",
+			"setup-2",
+			"whats-new-in-v12",
+			"übersicht-café",
+			"-getting-started",
+			"snake_case-and-spaces",
+			"-dashes-",
+		}, result)
+	})
+
+	t.Run("Hugo", func(t *testing.T) {
+		//GIVEN
+		slugger, err := NewSlugger(HugoSlugStyle)
+		require.NoError(t, err)
+
+		//WHEN
+		result := slugs(slugger, headings)
+
+		//THEN
+		assert.Equal(t, []string{
+			"setup",
+			"setup-1",
+			"setup-1-1",
+			"setup-2",
+			"whats-new-in-v12",
+			"übersicht--café",
+			"-getting-started",
+			"snake_case--and--spaces",
+			"--dashes--",
+		}, result)
+	})
+
+	t.Run("Unknown Style", func(t *testing.T) {
+		_, err := NewSlugger("unknown")
+		assert.Error(t, err)
+	})
+
+	t.Run("Encoded Anchor", func(t *testing.T) {
+		assert.True(t, headerExists("#%C3%BCbersicht--caf%C3%A9", Headers{Header{Text: "Übersicht & Café"}}, nil))
+		assert.True(t, headerExists("#setup-1", Headers{Header{Text: "Setup"}, Header{Text: "Setup"}}, nil))
+		assert.False(t, headerExists("#setup-2", Headers{Header{Text: "Setup"}, Header{Text: "Setup"}}, nil))
+	})
+	t.Run("Explicit IDs", func(t *testing.T) {
+		//GIVEN
+		headers := Headers{
+			Header{Text: "Installation {#setup}", ID: "setup"},
+			Header{ID: "legacy-anchor"},
+		}
+		github, err := NewSlugger(GitHubSlugStyle)
+		require.NoError(t, err)
+		hugo, err := NewSlugger(HugoSlugStyle)
+		require.NoError(t, err)
+		docusaurus, err := NewSlugger(DocusaurusSlugStyle)
+		require.NoError(t, err)
+
+		//WHEN
+		githubAnchors := headerAnchors(github, headers)
+		hugoAnchors := headerAnchors(hugo, headers)
+		docusaurusAnchors := headerAnchors(docusaurus, headers)
+
+		//THEN
+		assert.ElementsMatch(t, []string{"legacy-anchor", "installation-setup"}, githubAnchors)
+		assert.ElementsMatch(t, []string{"legacy-anchor", "setup"}, hugoAnchors)
+		assert.ElementsMatch(t, []string{"legacy-anchor", "setup"}, docusaurusAnchors)
+	})
+}
@@ -2,6 +2,7 @@ package pkg
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/olekukonko/tablewriter"
@@ -10,6 +11,9 @@ import (
 type FileStats struct {
 	SuccessLinks SuccessLinks
 	FailedLinks  FailedLinks
+	WarningLinks WarningLinks
+	InfoLinks    InfoLinks
+	SkippedLinks SkippedLinks
 }
 
 type SuccessLinks struct {
@@ -22,17 +26,45 @@ type FailedLinks struct {
 	Links []Link
 }
 
+// WarningLinks are valid links with problems of the warning severity
+type WarningLinks struct {
+	Count int
+	Links []Link
+}
+
+// InfoLinks are valid links with problems of the info severity
+type InfoLinks struct {
+	Count int
+	Links []Link
+}
+
+// SkippedLinks are links which weren't checked because of milv-disable comments
+type SkippedLinks struct {
+	Count int
+	Links []Link
+}
+
 type FilesStats []*FileStats
 
 func NewFileStats(file *File) *FileStats {
 	fileStat := &FileStats{}
 	for _, link := range file.Links {
-		if link.Result.Status {
-			fileStat.SuccessLinks.Count++
-			fileStat.SuccessLinks.Links = append(fileStat.SuccessLinks.Links, link)
-		} else {
+		switch {
+		case link.Result.Skipped:
+			fileStat.SkippedLinks.Count++
+			fileStat.SkippedLinks.Links = append(fileStat.SkippedLinks.Links, link)
+		case !link.Result.Status:
 			fileStat.FailedLinks.Count++
 			fileStat.FailedLinks.Links = append(fileStat.FailedLinks.Links, link)
+		case link.Result.Severity == WarningSeverity:
+			fileStat.WarningLinks.Count++
+			fileStat.WarningLinks.Links = append(fileStat.WarningLinks.Links, link)
+		case link.Result.Severity == InfoSeverity:
+			fileStat.InfoLinks.Count++
+			fileStat.InfoLinks.Links = append(fileStat.InfoLinks.Links, link)
+		default:
+			fileStat.SuccessLinks.Count++
+			fileStat.SuccessLinks.Links = append(fileStat.SuccessLinks.Links, link)
 		}
 	}
 	return fileStat
@@ -50,21 +82,20 @@ func NewFilesStats(files Files) FilesStats {
 	return fileStats
 }
 
-func writeStats(file *File) {
-	fmt.Printf("----- %s - status: %v\n", file.RelPath, file.Status)
+func writeStats(w io.Writer, file *File) {
+	fmt.Fprintf(w, "----- %s - status: %v\n", file.RelPath, file.Status)
 	for _, link := range file.Links {
-		if link.TypeOf == ExternalLink {
-			fmt.Printf("- %s", link.AbsPath)
-		} else {
-			fmt.Printf("- %s", link.RelPath)
+		fmt.Fprintf(w, "- %s", linkPath(link))
+		if position := linkPosition(link); position != "" {
+			fmt.Fprintf(w, " (%s)", position)
 		}
-		fmt.Printf(" - status: %v", link.Result.Status)
+		fmt.Fprintf(w, " - status: %s", linkStatus(link))
 		if link.Result.Message != "" {
-			fmt.Printf(", message: %s", link.Result.Message)
+			fmt.Fprintf(w, ", message: %s", link.Result.Message)
 		}
-		fmt.Printf("\n")
+		fmt.Fprintf(w, "\n")
 	}
-	fmt.Printf("\n")
+	fmt.Fprintf(w, "\n")
 }
 
 func summaryOfFile(file *File) {
@@ -72,55 +103,49 @@ func summaryOfFile(file *File) {
 
 	data := [][]string{}
 	for _, link := range file.Links {
-		var path string
-		if link.TypeOf == ExternalLink {
-			path = link.AbsPath
-		} else {
-			path = link.RelPath
-		}
 		data = append(data, []string{
-			path,
+			linkPosition(link),
+			linkPath(link),
 			link.Result.Message,
-			fmt.Sprintf("%v", link.Result.Status),
+			linkStatus(link),
 		})
 	}
 
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Link", "Description", "Status"})
+	table.SetHeader([]string{"Line", "Link", "Description", "Status"})
 	table.SetRowLine(true)
 	table.AppendBulk(data)
 	table.Render()
 }
 
-func summaryOfFiles(files Files) bool {
+// summaryOfFiles writes the table of links with problems of the failOn severity or more important ones,
+// and returns true if there are any
+func summaryOfFiles(w io.Writer, files Files, failOn Severity) bool {
 	failed := false
 
 	data := [][]string{}
 	for _, file := range files {
-		if len(file.Stats.FailedLinks.Links) > 0 {
-			failed = true
-			for _, link := range file.Stats.FailedLinks.Links {
-				var path string
-				if link.TypeOf == ExternalLink {
-					path = link.AbsPath
-				} else {
-					path = link.RelPath
-				}
-				data = append(data, []string{
-					file.RelPath,
-					path,
-					link.Result.Message,
-				})
+		for _, link := range file.Links {
+			if !link.Result.fails(failOn) {
+				continue
 			}
+			failed = true
+			data = append(data, []string{
+				file.RelPath,
+				linkPosition(link),
+				linkPath(link),
+				link.Result.Message,
+				string(link.Result.severity()),
+			})
 		}
 	}
 
 	if failed {
-		fmt.Printf("#################################################\n")
-		fmt.Printf("#                     SUMMARY                   #\n")
-		fmt.Printf("#################################################\n\n")
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{"File", "Link", "Description"})
+		fmt.Fprintf(w, "#################################################\n")
+		fmt.Fprintf(w, "#                     SUMMARY                   #\n")
+		fmt.Fprintf(w, "#################################################\n\n")
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{"File", "Line", "Link", "Description", "Severity"})
 		table.SetAutoMergeCells(true)
 		table.SetRowLine(true)
 		table.AppendBulk(data)
@@ -129,3 +154,31 @@ func summaryOfFiles(files Files) bool {
 
 	return failed
 }
+
+// linkPosition returns the line and the column of the link, if they're known
+func linkPosition(link Link) string {
+	if link.Line == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", link.Line, link.Column)
+}
+
+// linkStatus returns the status of the link, skipped if the link wasn't checked,
+// or the severity of the problem if the link is valid anyway
+func linkStatus(link Link) string {
+	if link.Result.Skipped {
+		return "skipped"
+	}
+	if severity := link.Result.severity(); severity == WarningSeverity || severity == InfoSeverity {
+		return string(severity)
+	}
+	return fmt.Sprintf("%v", link.Result.Status)
+}
+
+// linkPath returns the path of the link as it is written in the file
+func linkPath(link Link) string {
+	if link.TypeOf == ExternalLink {
+		return link.AbsPath
+	}
+	return link.RelPath
+}
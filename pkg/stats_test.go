@@ -25,6 +25,9 @@ func TestStats(t *testing.T) {
 						AbsPath: "https://twitter.com",
 						Config:  &LinkConfig{},
 						TypeOf:  ExternalLink,
+						Line:    7,
+						Column:  19,
+						Text:    "[Twitter](https://twitter.com)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -33,6 +36,9 @@ func TestStats(t *testing.T) {
 						AbsPath: "https://github.com",
 						Config:  &LinkConfig{},
 						TypeOf:  ExternalLink,
+						Line:    9,
+						Column:  18,
+						Text:    "[Github](https://github.com)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -46,9 +52,13 @@ func TestStats(t *testing.T) {
 						AbsPath: "https://httpbin.org/status/404",
 						Config:  &LinkConfig{},
 						TypeOf:  ExternalLink,
+						Line:    11,
+						Column:  29,
+						Text:    "[Link](https://httpbin.org/status/404)",
 						Result: LinkResult{
 							Status:  false,
 							Message: "404 Not Found",
+							Kind:    HTTPStatus,
 						},
 					},
 				},
@@ -56,6 +66,8 @@ func TestStats(t *testing.T) {
 		}
 
 		file.Run()
+		clearRedirects(file.Stats.SuccessLinks.Links)
+		clearRedirects(file.Stats.FailedLinks.Links)
 
 		require.NoError(t, err)
 		assert.Equal(t, expected, file.Stats)
@@ -73,6 +85,9 @@ func TestStats(t *testing.T) {
 						AbsPath: "test-markdowns/external_links.md",
 						RelPath: "../external_links.md",
 						TypeOf:  InternalLink,
+						Line:    7,
+						Column:  1,
+						Text:    "[../external_links.md](../external_links.md)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -82,6 +97,9 @@ func TestStats(t *testing.T) {
 						AbsPath: "test-markdowns/sub_path/sub_sub_path/without_links.md",
 						RelPath: "sub_sub_path/without_links.md",
 						TypeOf:  InternalLink,
+						Line:    9,
+						Column:  1,
+						Text:    "[sub_sub_path/without_links.md](sub_sub_path/without_links.md)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -91,6 +109,9 @@ func TestStats(t *testing.T) {
 						AbsPath: "test-markdowns/sub_path/absolute_path.md",
 						RelPath: "absolute_path.md",
 						TypeOf:  InternalLink,
+						Line:    11,
+						Column:  1,
+						Text:    "[absolute_path.md](absolute_path.md)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -105,9 +126,13 @@ func TestStats(t *testing.T) {
 						AbsPath: "test-markdowns/sub_path/invalid.md",
 						RelPath: "invalid.md",
 						TypeOf:  InternalLink,
+						Line:    13,
+						Column:  1,
+						Text:    "[Invalid internal link](invalid.md)",
 						Result: LinkResult{
 							Status:  false,
 							Message: "The specified file doesn't exist",
+							Kind:    MissingFile,
 						},
 						Config: &LinkConfig{},
 					},
@@ -116,6 +141,8 @@ func TestStats(t *testing.T) {
 		}
 
 		file.Run()
+		clearRedirects(file.Stats.SuccessLinks.Links)
+		clearRedirects(file.Stats.FailedLinks.Links)
 
 		require.NoError(t, err)
 		assert.Equal(t, expected, file.Stats)
@@ -132,6 +159,9 @@ func TestStats(t *testing.T) {
 					Link{
 						AbsPath: "https://github.com",
 						TypeOf:  ExternalLink,
+						Line:    13,
+						Column:  17,
+						Text:    "[link](https://github.com)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -140,6 +170,9 @@ func TestStats(t *testing.T) {
 					Link{
 						AbsPath: "https://github.com",
 						TypeOf:  ExternalLink,
+						Line:    21,
+						Column:  10,
+						Text:    "[strange](https://github.com)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -148,6 +181,9 @@ func TestStats(t *testing.T) {
 					Link{
 						RelPath: "#first-header",
 						TypeOf:  HashInternalLink,
+						Line:    27,
+						Column:  24,
+						Text:    "[link](#first-header)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -156,6 +192,9 @@ func TestStats(t *testing.T) {
 					Link{
 						RelPath: "#second-header",
 						TypeOf:  HashInternalLink,
+						Line:    29,
+						Column:  25,
+						Text:    "[link](#second-header)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -164,6 +203,9 @@ func TestStats(t *testing.T) {
 					Link{
 						RelPath: "#third-header",
 						TypeOf:  HashInternalLink,
+						Line:    31,
+						Column:  32,
+						Text:    "[link](#third-header)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -172,6 +214,9 @@ func TestStats(t *testing.T) {
 					Link{
 						RelPath: "#header-with-block",
 						TypeOf:  HashInternalLink,
+						Line:    35,
+						Column:  29,
+						Text:    "[link](#header-with-block)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -180,6 +225,9 @@ func TestStats(t *testing.T) {
 					Link{
 						RelPath: "#header-with-link",
 						TypeOf:  HashInternalLink,
+						Line:    37,
+						Column:  28,
+						Text:    "[link](#header-with-link)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -188,6 +236,9 @@ func TestStats(t *testing.T) {
 					Link{
 						RelPath: "#very-strange-header-really-people-create-headers-look-like-this",
 						TypeOf:  HashInternalLink,
+						Line:    39,
+						Column:  31,
+						Text:    "[link](#very-strange-header-really-people-create-headers-look-like-this)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -201,9 +252,13 @@ func TestStats(t *testing.T) {
 					Link{
 						RelPath: "#header",
 						TypeOf:  HashInternalLink,
+						Line:    33,
+						Column:  29,
+						Text:    "[link](#header)",
 						Result: LinkResult{
 							Status:  false,
 							Message: "The specified header doesn't exist in this file",
+							Kind:    MissingHeader,
 						},
 						Config: &LinkConfig{},
 					},
@@ -229,6 +284,9 @@ func TestStats(t *testing.T) {
 						AbsPath: "test-markdowns/external_links.md",
 						RelPath: "/external_links.md",
 						TypeOf:  InternalLink,
+						Line:    3,
+						Column:  28,
+						Text:    "[/external_links.md](/external_links.md)",
 						Result: LinkResult{
 							Status: true,
 						},
@@ -0,0 +1,114 @@
+package pkg
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+const (
+	DisableNextLineDirective = "milv-disable-next-line"
+	DisableDirective         = "milv-disable"
+	EnableDirective          = "milv-enable"
+	DisableFileDirective     = "milv-disable-file"
+
+	// directivePattern is the directive at the start of the text of the comment, other text may follow it
+	directivePattern = `^\s*(milv-(?:disable-next-line|disable-file|disable|enable))\b`
+)
+
+// Directive is the milv directive in the comment of the file
+type Directive struct {
+	Name string
+	// Line is the line where the comment ends, milv-disable-next-line skips links in the line after it
+	Line int
+}
+
+// DirectiveParser is implemented by source parsers which find directives in comments of the file.
+// Files of formats without it can't skip links with comments.
+type DirectiveParser interface {
+	Directives(content string) []Directive
+}
+
+// commentDirective returns the directive of the comment with the given text, without the comment syntax
+func commentDirective(comment string, line int) (Directive, bool) {
+	matches := regexp.MustCompile(directivePattern).FindStringSubmatch(comment)
+	if matches == nil {
+		return Directive{}, false
+	}
+	return Directive{Name: matches[1], Line: line}, true
+}
+
+// suppressions holds lines, where links aren't checked, with directives which suppress them
+type suppressions struct {
+	file  string
+	lines map[int]string
+	// disabled are ranges of lines between milv-disable and milv-enable, the range without the end is open
+	disabled [][2]int
+}
+
+// newSuppressions finds lines suppressed by the directives
+func newSuppressions(directives []Directive) suppressions {
+	sort.SliceStable(directives, func(i, j int) bool {
+		return directives[i].Line < directives[j].Line
+	})
+
+	result := suppressions{lines: map[int]string{}}
+	disabledFrom := 0
+	for _, directive := range directives {
+		switch directive.Name {
+		case DisableFileDirective:
+			result.file = DisableFileDirective
+		case DisableNextLineDirective:
+			result.lines[directive.Line+1] = DisableNextLineDirective
+		case DisableDirective:
+			if disabledFrom == 0 {
+				disabledFrom = directive.Line
+			}
+		case EnableDirective:
+			if disabledFrom != 0 {
+				result.disabled = append(result.disabled, [2]int{disabledFrom, directive.Line - 1})
+				disabledFrom = 0
+			}
+		}
+	}
+	if disabledFrom != 0 {
+		result.disabled = append(result.disabled, [2]int{disabledFrom, 0})
+	}
+	return result
+}
+
+// directive returns the directive which suppresses links in the line
+func (s suppressions) directive(line int) (string, bool) {
+	if s.file != "" {
+		return s.file, true
+	}
+	if directive, found := s.lines[line]; found {
+		return directive, true
+	}
+	for _, disabled := range s.disabled {
+		if line >= disabled[0] && (disabled[1] == 0 || line <= disabled[1]) {
+			return DisableDirective, true
+		}
+	}
+	return "", false
+}
+
+// skipSuppressedLinks marks links suppressed by directives in comments as skipped, so they are reported, but not checked
+func skipSuppressedLinks(links Links, parser SourceParser, content string) Links {
+	directiveParser, ok := parser.(DirectiveParser)
+	if !ok {
+		return links
+	}
+
+	suppressions := newSuppressions(directiveParser.Directives(content))
+	for i, link := range links {
+		if directive, suppressed := suppressions.directive(link.Line); suppressed {
+			links[i].Result = LinkResult{
+				Status:  true,
+				Skipped: true,
+				Message: fmt.Sprintf("Skipped by the %s comment", directive),
+			}
+		}
+	}
+	return links
+}
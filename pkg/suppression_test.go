@@ -0,0 +1,112 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuppressions(t *testing.T) {
+	t.Run("Directives", func(t *testing.T) {
+		//GIVEN
+		content, err := readMarkdown("test-markdowns/suppressions.md")
+		require.NoError(t, err)
+
+		//WHEN
+		result := newSuppressions((&Parser{}).Directives(content))
+
+		//THEN
+		assert.Equal(t, suppressions{
+			lines:    map[int]string{4: DisableNextLineDirective},
+			disabled: [][2]int{{8, 9}},
+		}, result)
+		for line, expected := range map[int]bool{3: false, 4: true, 8: true, 9: true, 10: false, 15: false, 18: false} {
+			_, suppressed := result.directive(line)
+			assert.Equal(t, expected, suppressed, line)
+		}
+	})
+
+	t.Run("Disable File", func(t *testing.T) {
+		//GIVEN
+		content := "# Header\n\n[link](#missing)\n\n<!-- milv-disable-file -->\n"
+
+		//WHEN
+		result := newSuppressions((&Parser{}).Directives(content))
+
+		//THEN
+		directive, suppressed := result.directive(3)
+		assert.True(t, suppressed)
+		assert.Equal(t, DisableFileDirective, directive)
+	})
+
+	t.Run("Code Spans", func(t *testing.T) {
+		//GIVEN
+		content := "| Comment | Skipped links |\n| --- | --- |\n| `<!-- milv-disable-file -->` | All links |\n\n" +
+			"Write `<!-- milv-disable -->` before [links](#missing). <!-- milv-disable-next-line -->\n[link](#missing)\n"
+
+		//WHEN
+		result := (&Parser{}).Directives(content)
+
+		//THEN
+		assert.Equal(t, []Directive{{Name: DisableNextLineDirective, Line: 5}}, result)
+	})
+
+	t.Run("Short And Unterminated Comments", func(t *testing.T) {
+		//GIVEN
+		content := "<!-->\n\n[link](#missing) <!---> <!-->\n\n<!-- milv-disable-next-line -->\n[link](#missing)\n\n<!-- milv-disable-file\n"
+
+		//WHEN
+		result := (&Parser{}).Directives(content)
+
+		//THEN
+		assert.Equal(t, []Directive{{Name: DisableNextLineDirective, Line: 5}}, result)
+	})
+
+	t.Run("Other Formats", func(t *testing.T) {
+		//GIVEN
+		mdx := "{/* milv-disable-next-line */}\nhttps://a.example.com\n\n`{/* milv-disable-file */}`\n"
+		rst := ".. milv-disable-next-line\nhttps://b.example.com\n\nExample::\n\n    .. milv-disable-file\n\n``.. milv-disable``\n"
+		asciidoc := "// milv-disable-next-line\nhttps://c.example.com\n\n----\n// milv-disable-file\n----\n`// milv-disable`\n"
+
+		//WHEN
+		mdxResult := mdxParser{&Parser{}}.Directives(mdx)
+		rstResult := rstParser{&Parser{CodeBlocks: true}}.Directives(rst)
+		asciidocResult := asciidocParser{&Parser{}}.Directives(asciidoc)
+
+		//THEN
+		expected := []Directive{{Name: DisableNextLineDirective, Line: 1}}
+		assert.Equal(t, expected, mdxResult)
+		assert.Equal(t, expected, rstResult)
+		assert.Equal(t, expected, asciidocResult)
+	})
+
+	t.Run("Skipped Links", func(t *testing.T) {
+		//GIVEN
+		file, err := NewFile("test-markdowns/suppressions.md", Links{}, FileConfig{})
+		require.NoError(t, err)
+
+		//WHEN
+		file.Run()
+
+		//THEN
+		skipped := func(directive string) LinkResult {
+			return LinkResult{Status: true, Skipped: true, Message: "Skipped by the " + directive + " comment"}
+		}
+		expected := map[string]LinkResult{
+			"https://nvd.nist.gov/vuln-metrics/cvss/v3-calculator": skipped(DisableNextLineDirective),
+			"missing.md":      {Status: false, Message: "The specified file doesn't exist", Kind: MissingFile},
+			"#dead":           skipped(DisableDirective),
+			"dead.md":         skipped(DisableDirective),
+			"#missing-header": {Status: false, Message: "The specified header doesn't exist in this file", Kind: MissingHeader},
+			"#suppressions":   {Status: true},
+		}
+		require.Len(t, file.Links, len(expected))
+		for _, link := range file.Links {
+			assert.Equal(t, expected[linkPath(link)], link.Result, linkPath(link))
+		}
+		assert.Equal(t, 3, file.Stats.SkippedLinks.Count)
+		assert.Equal(t, 1, file.Stats.SuccessLinks.Count)
+		assert.Equal(t, 2, file.Stats.FailedLinks.Count)
+	})
+}
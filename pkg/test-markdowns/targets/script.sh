@@ -0,0 +1,5 @@
+#!/bin/sh
+set -e
+echo "one"
+echo "two"
+echo "three"
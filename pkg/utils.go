@@ -5,13 +5,18 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"net/url"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
 const (
 	codeBlockPattern = `(?m)^(.*\x60{3}).*\n(.*|\n)+?\n(.*\x60{3})$`
+	// the line anchor of GitHub, such as L10, L10-L20 or L10C5-L20C3
+	lineAnchorPattern = `^L(\d+)(?:C\d+)?(?:-L(\d+)(?:C\d+)?)?$`
 )
 
 func fileExists(file string) error {
@@ -23,14 +28,83 @@ func fileExists(file string) error {
 	return nil
 }
 
-func headerExists(link string, headers []string) bool {
-	link = strings.TrimPrefix(link, "#")
-	for _, header := range headers {
-		if link == strings.ToLower(strings.Replace(header, " ", "-", -1)) {
-			return true
+func headerExists(link string, headers Headers, slugger Slugger) bool {
+	if slugger == nil {
+		slugger = githubSlugger{}
+	}
+
+	anchor := strings.TrimPrefix(link, "#")
+	if unescaped, err := url.PathUnescape(anchor); err == nil {
+		anchor = unescaped
+	}
+	return contains(headerAnchors(slugger, headers), anchor)
+}
+
+// closestHeader returns the anchor of the header which is similar to the missing one, or an empty string
+func closestHeader(link string, headers Headers, slugger Slugger) string {
+	if slugger == nil {
+		slugger = githubSlugger{}
+	}
+	return similarAnchor(headerAnchors(slugger, headers), strings.TrimPrefix(link, "#"))
+}
+
+// similarAnchor returns the anchor with the fewest edits from the missing one, such as a typo or a changed word.
+// Anchors which need edits of more than a third of the missing anchor aren't similar, so they aren't returned.
+func similarAnchor(anchors []string, anchor string) string {
+	similar, best := "", utf8.RuneCountInString(anchor)/3
+	for _, candidate := range anchors {
+		if distance := editDistance(anchor, candidate); distance <= best && candidate != anchor {
+			similar, best = candidate, distance-1
 		}
 	}
-	return false
+	return similar
+}
+
+// editDistance is the Levenshtein distance of the strings counted in characters
+func editDistance(a, b string) int {
+	first, second := []rune(a), []rune(b)
+	previous := make([]int, len(second)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(first); i++ {
+		current := make([]int, len(second)+1)
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous = current
+	}
+	return previous[len(second)]
+}
+
+// lineExists checks if the file has the lines of the line anchor
+func lineExists(anchor, content string) bool {
+	matches := regexp.MustCompile(lineAnchorPattern).FindStringSubmatch(anchor)
+	if matches == nil {
+		return false
+	}
+
+	lines := strings.Count(content, "\n")
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		lines++
+	}
+	first, _ := strconv.Atoi(matches[1])
+	last := first
+	if matches[2] != "" {
+		last, _ = strconv.Atoi(matches[2])
+	}
+	return first >= 1 && first <= last && last <= lines
 }
 
 func unique(elements []string) []string {
@@ -51,14 +125,7 @@ func unique(elements []string) []string {
 func removeIgnoredFiles(filePaths, filesToIgnore []string) []string {
 	var newFilePaths []string
 	for _, file := range filePaths {
-		exists := false
-		for _, fileToIgnore := range filesToIgnore {
-			if strings.Contains(file, fileToIgnore) {
-				exists = true
-				break
-			}
-		}
-		if !exists {
+		if !isFileIgnored(file, filesToIgnore) {
 			newFilePaths = append(newFilePaths, file)
 		}
 	}
@@ -73,9 +140,12 @@ func readMarkdown(filePath string) (string, error) {
 	return string(content), nil
 }
 
+// removeCodeBlocks keeps new lines of the removed code blocks, so lines of links don't change
 func removeCodeBlocks(markdown string) string {
 	re := regexp.MustCompile(codeBlockPattern)
-	return re.ReplaceAllString(markdown, "")
+	return re.ReplaceAllStringFunc(markdown, func(codeBlock string) string {
+		return strings.Repeat("\n", strings.Count(codeBlock, "\n"))
+	})
 }
 
 func contains(slice []string, value string) bool {
@@ -19,17 +19,17 @@ func TestUtils(t *testing.T) {
 
 	t.Run("Header Exists", func(t *testing.T) {
 		header := "#first-header"
-		existHeaders := Headers{"First Header", "Second Header", "Third Header"}
+		existHeaders := Headers{Header{Text: "First Header"}, Header{Text: "Second Header"}, Header{Text: "Third Header"}}
 
-		result := headerExists(header, existHeaders)
+		result := headerExists(header, existHeaders, githubSlugger{})
 		assert.Equal(t, true, result)
 	})
 
 	t.Run("Header Not Exists", func(t *testing.T) {
 		header := "#non-exist-header"
-		existHeaders := Headers{"First Header", "Second Header", "Third Header"}
+		existHeaders := Headers{Header{Text: "First Header"}, Header{Text: "Second Header"}, Header{Text: "Third Header"}}
 
-		result := headerExists(header, existHeaders)
+		result := headerExists(header, existHeaders, githubSlugger{})
 		assert.Equal(t, false, result)
 	})
 
@@ -1,15 +1,17 @@
 package pkg
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
-
-	"github.com/schollz/closestmatch"
 )
 
 type Waiter interface {
@@ -17,8 +19,32 @@ type Waiter interface {
 }
 
 type Validator struct {
-	client http.Client
-	waiter Waiter
+	client  http.Client
+	waiter  Waiter
+	pool    *workerPool
+	limiter *HostLimiter
+	cache   *ResultCache
+	parser  *Parser
+	slugger Slugger
+	renames *Renames
+	// severities override severities of problems of these kinds
+	severities Severities
+	// dirPath is the directory of the file with links, relative links are written relative to it
+	dirPath string
+	// fix tells links are fixed, so fixes of external links, such as https upgrades, are looked for
+	fix       bool
+	redirects RedirectConfig
+}
+
+// checkResult is the response of the server, independent of the link config
+type checkResult struct {
+	StatusCode int
+	Message    string
+	Anchors    []string
+	// Redirects are redirects followed to get the response, in the order they were followed
+	Redirects []Redirect
+	// Failure is the kind of the failure which the status code doesn't tell, such as the redirect loop
+	Failure FailureKind
 }
 
 func NewValidator(client http.Client, limiter Waiter) *Validator {
@@ -37,19 +63,31 @@ func (v *Validator) Links(links []Link, optionalHeaders ...Headers) []Link {
 		headersExist = len(headers) > 0
 	}
 
-	var validatedLinks []Link
-	for _, link := range links {
-		if link.TypeOf == ExternalLink {
-			link, _ = v.externalLink(link)
-			validatedLinks = append(validatedLinks, link)
+	results := make([]Link, len(links))
+	skipped := make([]bool, len(links))
+	v.pool.Run(len(links), func(i int) {
+		link := links[i]
+		if link.Result.Skipped {
+			results[i] = link
+		} else if link.TypeOf == ExternalLink {
+			results[i], _ = v.externalLink(link)
 		} else if link.TypeOf == InternalLink {
-			link, _ = v.internalLink(link)
-			validatedLinks = append(validatedLinks, link)
+			results[i], _ = v.internalLink(link)
+		} else if link.TypeOf == ReferenceLink {
+			// the parser already found the problem
+			results[i] = link
+		} else if headersExist {
+			results[i], _ = v.hashInternalLink(link, headers)
 		} else {
-			if headersExist {
-				link, _ = v.hashInternalLink(link, headers)
-				validatedLinks = append(validatedLinks, link)
-			}
+			skipped[i] = true
+		}
+	})
+
+	var validatedLinks []Link
+	for i, link := range results {
+		if !skipped[i] {
+			link.Result = v.severities.apply(link.Result)
+			validatedLinks = append(validatedLinks, link)
 		}
 	}
 	return validatedLinks
@@ -69,21 +107,92 @@ func (v *Validator) externalLink(link Link) (Link, error) {
 		return link, nil
 	}
 
-	var status bool
-	message := ""
-
 	url, err := url.Parse(link.AbsPath)
 	if err != nil {
 		link.Result.Status = false
 		link.Result.Message = err.Error()
+		link.Result.Kind = InvalidURL
 		return link, err
 	}
+
+	allowRedirect := false
+	if link.Config != nil && link.Config.AllowRedirect != nil {
+		allowRedirect = *link.Config.AllowRedirect
+	}
+
+	checkAnchor := false
+	if !allowRedirect && url.Fragment != "" {
+		checkAnchor, _ = regexp.MatchString(`[a-zA-Z]`, string(url.Fragment[0]))
+	}
+
+	result := v.cache.Get(cacheKey(url, checkAnchor), func() checkResult {
+		return v.request(link, url, checkAnchor)
+	})
+
+	link.Result = result.linkResult(url.Fragment, allowRedirect, checkAnchor)
+	link.Result.Redirects = result.Redirects
+	if !allowRedirect {
+		link.Result = v.redirects.apply(link.Result, result.Redirects)
+	}
+	if v.fix {
+		link.Result.Fix = v.externalLinkFix(link, url, result, checkAnchor)
+	}
+	return link, nil
+}
+
+// externalLinkFix returns the link which replaces the link: the final URL of permanent redirects,
+// the similar anchor of the website instead of the missing one, or the https URL instead of the http one,
+// if the website responds with success to it. Without fixes, it returns an empty string.
+func (v *Validator) externalLinkFix(link Link, url *url.URL, result checkResult, checkAnchor bool) string {
+	fix, fragment, hasFragment := link.AbsPath, "", false
+	if i := strings.Index(fix, "#"); i >= 0 {
+		fix, fragment, hasFragment = fix[:i], fix[i+1:], true
+	}
+
+	// the query isn't sent with the request, so the redirect of the link with the query isn't known
+	if location, found := result.permanentRedirect(); found && url.RawQuery == "" {
+		fix = location
+		if i := strings.Index(location, "#"); i >= 0 {
+			fix, fragment, hasFragment = location[:i], location[i+1:], true
+		}
+	} else if url.Scheme == "http" {
+		upgraded := *url
+		upgraded.Scheme = "https"
+		upgradeResult := v.cache.Get(cacheKey(&upgraded, false), func() checkResult {
+			return v.request(link, &upgraded, false)
+		})
+		if upgradeResult.isSuccess(false) {
+			fix = "https" + strings.TrimPrefix(fix, "http")
+		}
+	}
+
+	if checkAnchor && result.isSuccess(false) && !contains(result.Anchors, url.Fragment) {
+		if similar := similarAnchor(result.Anchors, url.Fragment); similar != "" {
+			fragment = similar
+		}
+	}
+
+	if hasFragment {
+		fix += "#" + fragment
+	}
+	if fix == link.AbsPath {
+		return ""
+	}
+	return fix
+}
+
+func (v *Validator) request(link Link, url *url.URL, checkAnchor bool) checkResult {
+	var result checkResult
 	absPath := fmt.Sprintf("%s://%s%s", url.Scheme, url.Host, url.Path)
 
+	// links are validated concurrently, so the shared client can't be modified
+	var redirects []Redirect
+	client := v.client
+	client.CheckRedirect = v.redirects.followRedirects(&redirects)
 	if link.Config != nil && link.Config.Timeout != nil && *link.Config.Timeout != 0 {
-		v.client.Timeout = time.Duration(int(time.Second) * (*link.Config.Timeout))
+		client.Timeout = time.Duration(int(time.Second) * (*link.Config.Timeout))
 	} else {
-		v.client.Timeout = time.Duration(int(time.Second) * 30)
+		client.Timeout = time.Duration(int(time.Second) * 30)
 	}
 
 	requestRepeats := 1
@@ -92,68 +201,89 @@ func (v *Validator) externalLink(link Link) (Link, error) {
 	}
 
 	for i := 0; i < requestRepeats; i++ {
-		resp, err := v.client.Get(absPath)
+		redirects = nil
+		release := v.limiter.Acquire(url.Host)
+		resp, err := client.Get(absPath)
+		release()
+		var redirectErr *redirectError
+		if errors.As(err, &redirectErr) {
+			// redirects don't change when the request is repeated
+			result = checkResult{Message: redirectErr.message, Redirects: redirects, Failure: redirectErr.kind}
+			break
+		}
 		if err != nil {
-			status = false
-			message = err.Error()
+			result = checkResult{Message: err.Error()}
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				result.Failure = RequestTimeout
+			}
 			continue
 		}
 
-		allowRedirect := false
-		if link.Config != nil && link.Config.AllowRedirect != nil {
-			allowRedirect = *link.Config.AllowRedirect
-		}
-
-		statusCode, http2xxPattern := strconv.Itoa(resp.StatusCode), `^2[0-9][0-9]`
-		if allowRedirect {
-			http2xxPattern = `^2[0-9][0-9]|^3[0-9][0-9]`
-		}
-
-		if match, _ := regexp.MatchString(http2xxPattern, statusCode); match && resp != nil {
-			status = true
-
-			if !allowRedirect && url.Fragment != "" {
-				match, _ = regexp.MatchString(`[a-zA-Z]`, string(url.Fragment[0]))
-				if !match {
-					break
-				}
+		result = checkResult{StatusCode: resp.StatusCode, Message: resp.Status, Redirects: redirects}
 
+		// the redirect is a valid answer as well, allow-redirect is applied per link
+		if match, _ := regexp.MatchString(`^[23][0-9][0-9]`, strconv.Itoa(resp.StatusCode)); match {
+			if checkAnchor && result.isSuccess(false) {
 				parser := &Parser{}
-				anchors := parser.Anchors(resp.Body)
-
-				if contains(anchors, url.Fragment) {
-					status = true
-				} else {
-					cm := closestmatch.New(anchors, []int{4, 3, 5})
-					closestAnchor := cm.Closest(url.Fragment)
-
-					status = false
-					if closestAnchor != "" {
-						message = fmt.Sprintf("The specified anchor doesn't exist on the website. Did you mean #%s?", closestAnchor)
-					} else {
-						message = "The specified anchor doesn't exist"
-					}
-				}
+				result.Anchors = parser.Anchors(resp.Body)
 			}
 
 			CloseBody(resp.Body)
 			break
 		} else if resp.StatusCode == http.StatusTooManyRequests {
-			status = false
-			message = "Too many requests"
-			v.waiter.Wait()
+			result.Message = "Too many requests"
+			if until, ok := retryAfter(resp.Header, time.Now()); ok && v.limiter != nil {
+				v.limiter.Delay(url.Host, until)
+			} else {
+				v.waiter.Wait()
+			}
 			CloseBody(resp.Body)
 			continue
 		} else {
-			status = false
-			message = resp.Status
 			CloseBody(resp.Body)
 		}
 	}
 
-	link.Result.Status = status
-	link.Result.Message = message
-	return link, nil
+	return result
+}
+
+func (r checkResult) isSuccess(allowRedirect bool) bool {
+	http2xxPattern := `^2[0-9][0-9]`
+	if allowRedirect {
+		http2xxPattern = `^2[0-9][0-9]|^3[0-9][0-9]`
+	}
+	match, _ := regexp.MatchString(http2xxPattern, strconv.Itoa(r.StatusCode))
+	return match
+}
+
+func (r checkResult) linkResult(fragment string, allowRedirect, checkAnchor bool) LinkResult {
+	if r.Failure != "" {
+		return LinkResult{Status: false, Message: r.Message, Kind: r.Failure}
+	}
+	if !r.isSuccess(allowRedirect) {
+		kind := HTTPStatus
+		if r.StatusCode == 0 {
+			kind = RequestError
+		} else if r.StatusCode == http.StatusTooManyRequests {
+			kind = TooManyRequests
+		}
+		return LinkResult{Status: false, Message: r.Message, Kind: kind}
+	}
+
+	if !checkAnchor || contains(r.Anchors, fragment) {
+		return LinkResult{Status: true}
+	}
+
+	// the suggested anchor is the same one which -fix writes
+	if similar := similarAnchor(r.Anchors, fragment); similar != "" {
+		return LinkResult{
+			Status:  false,
+			Message: fmt.Sprintf("The specified anchor doesn't exist on the website. Did you mean #%s?", similar),
+			Kind:    MissingAnchor,
+		}
+	}
+	return LinkResult{Status: false, Message: "The specified anchor doesn't exist", Kind: MissingAnchor}
 }
 
 func (v *Validator) internalLink(link Link) (Link, error) {
@@ -162,43 +292,91 @@ func (v *Validator) internalLink(link Link) (Link, error) {
 	}
 
 	splitted := strings.Split(link.AbsPath, "#")
+	// the query is used e.g. to display images in the raw form, it's not the part of the file name
+	filePath := strings.Split(splitted[0], "?")[0]
 
-	if err := fileExists(splitted[0]); err == nil {
+	if err := fileExists(filePath); err == nil {
 		link.Result.Status = true
 
 		if len(splitted) == 2 {
-			if !v.isHashInFile(splitted[0], splitted[1]) {
-				link.Result.Status = false
-				link.Result.Message = "The specified header doesn't exist in this file"
+			link.Result = v.anchorInFile(filePath, splitted[1])
+			if link.Result.Fix != "" {
+				link.Result.Fix = strings.Split(link.RelPath, "#")[0] + link.Result.Fix
 			}
 		}
 	} else {
 		link.Result.Status = false
 		link.Result.Message = "The specified file doesn't exist"
+		link.Result.Kind = MissingFile
+		if fix, found := v.renames.suggest(link, v.dirPath); found {
+			link.Result.Message = fmt.Sprintf("The specified file doesn't exist, it was moved. Did you mean %s?", fix)
+			link.Result.Fix = fix
+		}
 	}
 	return link, nil
 }
 
-func (*Validator) hashInternalLink(link Link, headers Headers) (Link, error) {
+func (v *Validator) hashInternalLink(link Link, headers Headers) (Link, error) {
 	if link.TypeOf != HashInternalLink {
 		return link, nil
 	}
 
-	if match := headerExists(link.RelPath, headers); match {
+	if match := headerExists(link.RelPath, headers, v.slugger); match {
 		link.Result.Status = true
 	} else {
 		link.Result.Status = false
 		link.Result.Message = "The specified header doesn't exist in this file"
+		link.Result.Kind = MissingHeader
+		if closest := closestHeader(link.RelPath, headers, v.slugger); closest != "" {
+			link.Result.Message = fmt.Sprintf("The specified header doesn't exist in this file. Did you mean #%s?", closest)
+			link.Result.Fix = "#" + closest
+		}
 	}
 	return link, nil
 }
 
-func (*Validator) isHashInFile(file, header string) bool {
-	markdown, err := readMarkdown(file)
+// anchorInFile checks the anchor of the link to the local file. How it's checked depends on the type of the file:
+// headers of source formats, such as markdown, IDs of elements of HTML files, and lines of other files, such as L10 or L10-L20.
+// The fix of the result is the anchor, such as #setup, which replaces the anchor of the link.
+func (v *Validator) anchorInFile(file, anchor string) LinkResult {
+	content, err := readMarkdown(file)
 	if err != nil {
-		return false
+		return LinkResult{Status: false, Message: err.Error(), Kind: MissingFile}
+	}
+
+	parser := v.parser
+	if parser == nil {
+		parser = &Parser{}
+	}
+
+	if extension := strings.ToLower(filepath.Ext(file)); extension == ".html" || extension == ".htm" {
+		if unescaped, err := url.PathUnescape(anchor); err == nil {
+			anchor = unescaped
+		}
+		if contains(parser.Anchors(io.NopCloser(strings.NewReader(content))), anchor) {
+			return LinkResult{Status: true}
+		}
+		return LinkResult{Status: false, Message: "The specified anchor doesn't exist in this file", Kind: MissingAnchor}
 	}
 
-	parser := Parser{}
-	return headerExists(header, parser.Headers(markdown))
+	if source, found := NewSourceParser(file, parser); found {
+		headers := source.Headers(content)
+		if headerExists(anchor, headers, v.slugger) {
+			return LinkResult{Status: true}
+		}
+		if closest := closestHeader(anchor, headers, v.slugger); closest != "" {
+			return LinkResult{
+				Status:  false,
+				Message: fmt.Sprintf("The specified header doesn't exist in this file. Did you mean #%s?", closest),
+				Kind:    MissingHeader,
+				Fix:     "#" + closest,
+			}
+		}
+		return LinkResult{Status: false, Message: "The specified header doesn't exist in this file", Kind: MissingHeader}
+	}
+
+	if lineExists(anchor, content) {
+		return LinkResult{Status: true}
+	}
+	return LinkResult{Status: false, Message: "The specified lines don't exist in this file", Kind: MissingAnchor}
 }
@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -56,12 +57,14 @@ func TestValidation(t *testing.T) {
 				Result: LinkResult{
 					Status:  false,
 					Message: "404 Not Found",
+					Kind:    HTTPStatus,
 				},
 			},
 		}
 
 		valid := NewValidator(client, waitMock)
 		result := valid.Links(links)
+		clearRedirects(result)
 
 		assert.Equal(t, expected, result)
 	})
@@ -122,6 +125,7 @@ func TestValidation(t *testing.T) {
 				Result: LinkResult{
 					Status:  false,
 					Message: "The specified file doesn't exist",
+					Kind:    MissingFile,
 				},
 			},
 			Link{
@@ -137,6 +141,7 @@ func TestValidation(t *testing.T) {
 				Result: LinkResult{
 					Status:  false,
 					Message: "The specified header doesn't exist in this file",
+					Kind:    MissingHeader,
 				},
 			},
 		}
@@ -149,13 +154,13 @@ func TestValidation(t *testing.T) {
 
 	t.Run("Hash Internal Links", func(t *testing.T) {
 		existHeaders := Headers{
-			"First Header",
-			"Second Header",
-			"Third Header",
-			"Header with link",
-			"Header with block",
-			"Very strange header really people create headers look like this",
-			"Links",
+			Header{Text: "First Header"},
+			Header{Text: "Second Header"},
+			Header{Text: "Third Header"},
+			Header{Text: "Header with link"},
+			Header{Text: "Header with block"},
+			Header{Text: "Very strange header (really, people create headers look like this)"},
+			Header{Text: "Links"},
 		}
 
 		links := []Link{
@@ -217,6 +222,7 @@ func TestValidation(t *testing.T) {
 				Result: LinkResult{
 					Status:  false,
 					Message: "The specified header doesn't exist in this file",
+					Kind:    MissingHeader,
 				},
 			},
 			Link{
@@ -248,6 +254,102 @@ func TestValidation(t *testing.T) {
 		assert.Equal(t, expected, result)
 	})
 
+	t.Run("Local Images", func(t *testing.T) {
+		//GIVEN
+		links := []Link{
+			Link{
+				RelPath: "images/logo.png?raw=true",
+				AbsPath: "test-markdowns/images/logo.png?raw=true",
+				TypeOf:  InternalLink,
+			},
+			Link{
+				RelPath: "images/missing.png",
+				AbsPath: "test-markdowns/images/missing.png",
+				TypeOf:  InternalLink,
+			},
+		}
+		validator := NewValidator(http.Client{}, nil)
+
+		//WHEN
+		result := validator.Links(links)
+
+		//THEN
+		require.Len(t, result, 2)
+		assert.Equal(t, LinkResult{Status: true}, result[0].Result)
+		assert.Equal(t, LinkResult{
+			Status:  false,
+			Message: "The specified file doesn't exist",
+			Kind:    MissingFile,
+		}, result[1].Result)
+	})
+
+	t.Run("Anchors Of Target Types", func(t *testing.T) {
+		//GIVEN
+		paths := []string{
+			"targets/page.html#installation",
+			"targets/page.html#legacy",
+			"targets/page.html#missing",
+			"targets/script.sh#L2-L5",
+			"targets/script.sh#L3C2",
+			"targets/script.sh#L4-L6",
+			"targets/script.sh#main",
+			"formats/guide.rst#installation-steps",
+			"hash_internal_links.md#first-header",
+			"hash_internal_links.md#L1",
+		}
+		var links []Link
+		for _, path := range paths {
+			links = append(links, Link{RelPath: path, AbsPath: "test-markdowns/" + path, TypeOf: InternalLink})
+		}
+		validator := NewValidator(http.Client{}, nil)
+
+		//WHEN
+		result := validator.Links(links)
+
+		//THEN
+		missingAnchor := LinkResult{Status: false, Message: "The specified anchor doesn't exist in this file", Kind: MissingAnchor}
+		missingLines := LinkResult{Status: false, Message: "The specified lines don't exist in this file", Kind: MissingAnchor}
+		missingHeader := LinkResult{Status: false, Message: "The specified header doesn't exist in this file", Kind: MissingHeader}
+		expected := []LinkResult{
+			{Status: true},
+			{Status: true},
+			missingAnchor,
+			{Status: true},
+			{Status: true},
+			missingLines,
+			missingLines,
+			{Status: true},
+			{Status: true},
+			missingHeader,
+		}
+		require.Len(t, result, len(expected))
+		for i, link := range result {
+			assert.Equal(t, expected[i], link.Result, link.RelPath)
+		}
+	})
+
+	t.Run("Reference Links", func(t *testing.T) {
+		//GIVEN
+		links := []Link{
+			Link{
+				RelPath: "undefined",
+				TypeOf:  ReferenceLink,
+				Result: LinkResult{
+					Status:  false,
+					Message: "The reference isn't defined in this file",
+					Kind:    UndefinedReference,
+				},
+			},
+		}
+		validator := NewValidator(http.Client{}, nil)
+
+		//WHEN
+		result := validator.Links(links)
+
+		//THEN
+		assert.Equal(t, links, result)
+	})
+
 	t.Run("Check if throttling works", func(t *testing.T) {
 		//GIVEN
 		requestRepeats := 5
@@ -275,6 +377,41 @@ func TestValidation(t *testing.T) {
 		assert.Equal(t, "Too many requests", outLink.Result.Message)
 		waitMock.AssertExpectations(t)
 	})
+
+	t.Run("Concurrent validation keeps links order", func(t *testing.T) {
+		//GIVEN
+		svc := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.URL.Path == "/missing" {
+				writer.WriteHeader(http.StatusNotFound)
+				return
+			}
+			writer.WriteHeader(http.StatusOK)
+		}))
+		defer svc.Close()
+
+		v := NewValidator(http.Client{}, &waitMock{})
+		v.pool = newWorkerPool(4)
+
+		var links []Link
+		var expected []Link
+		for i := 0; i < 10; i++ {
+			path := fmt.Sprintf("%s/page-%d", svc.URL, i)
+			links = append(links, Link{AbsPath: path, TypeOf: ExternalLink})
+			expected = append(expected, Link{AbsPath: path, TypeOf: ExternalLink, Result: LinkResult{Status: true}})
+		}
+		links = append(links, Link{AbsPath: svc.URL + "/missing", TypeOf: ExternalLink})
+		expected = append(expected, Link{
+			AbsPath: svc.URL + "/missing",
+			TypeOf:  ExternalLink,
+			Result:  LinkResult{Status: false, Message: "404 Not Found", Kind: HTTPStatus},
+		})
+
+		//WHEN
+		result := v.Links(links)
+
+		//THEN
+		assert.Equal(t, expected, result)
+	})
 }
 
 type waitMock struct {
@@ -284,3 +421,10 @@ type waitMock struct {
 func (m *waitMock) Wait() {
 	m.Called()
 }
+
+// clearRedirects removes redirect chains of links to websites, because websites change their redirects over time
+func clearRedirects(links []Link) {
+	for i := range links {
+		links[i].Result.Redirects = nil
+	}
+}